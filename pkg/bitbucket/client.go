@@ -0,0 +1,58 @@
+package bitbucket
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/rest"
+)
+
+// Client is a Bitbucket Server (Data Center) API client.
+// Client implements the same API as GitHub client.
+// Org and Repo are the project key and the repository slug.
+type Client struct {
+	rest   *rest.Client
+	logger *slog.Logger
+}
+
+type ParamNew struct {
+	// Token is a HTTP access token or a personal access token.
+	Token string
+	// BaseURL is the base URL of Bitbucket Server REST API. e.g. https://bitbucket.example.com/rest/api/1.0
+	BaseURL string
+	// Retry is the configuration to retry requests. If Retry is nil, requests aren't retried
+	Retry  *github.Retry
+	Logger *slog.Logger
+}
+
+func New(param *ParamNew) (*Client, error) {
+	if param.BaseURL == "" {
+		return nil, errors.New("the base URL of Bitbucket Server API is required")
+	}
+	return &Client{
+		rest: rest.New(&rest.ParamNew{
+			Name:    "Bitbucket Server",
+			BaseURL: param.BaseURL,
+			Header: http.Header{
+				"Authorization": []string{"Bearer " + param.Token},
+				"Accept":        []string{"application/json"},
+			},
+			Retry:  param.Retry,
+			Logger: param.Logger,
+		}),
+		logger: param.Logger,
+	}, nil
+}
+
+func repoPath(org, repo string) string {
+	return "projects/" + org + "/repos/" + repo
+}
+
+// page is a paged response of Bitbucket Server API.
+type page[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
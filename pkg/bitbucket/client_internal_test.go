@@ -0,0 +1,211 @@
+package bitbucket
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+type request struct {
+	Method string
+	Path   string
+}
+
+// newTestClient returns a client which sends requests to a test server.
+// handler returns the status code and the response body of each request.
+func newTestClient(t *testing.T, handler func(r *request) (int, string, http.Header)) (*Client, *[]*request) {
+	t.Helper()
+	requests := []*request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &request{
+			Method: r.Method,
+			Path:   r.URL.RequestURI(),
+		}
+		requests = append(requests, req)
+		code, body, header := handler(req)
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := New(&ParamNew{
+		Token:   "xxx",
+		BaseURL: srv.URL + "/rest/api/1.0",
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, err)
+	return client, &requests
+}
+
+func TestClient_CreateComment(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title   string
+		cmt     *github.Comment
+		handler func(r *request) (int, string, http.Header)
+		exp     []string
+	}{
+		{
+			title: "create a pull request comment",
+			cmt: &github.Comment{
+				Org:      "FOO",
+				Repo:     "bar",
+				PRNumber: 1,
+				Body:     "hello",
+			},
+			exp: []string{"POST /rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments"},
+		},
+		{
+			title: "update a pull request comment",
+			cmt: &github.Comment{
+				Org:       "FOO",
+				Repo:      "bar",
+				PRNumber:  1,
+				CommentID: 10,
+				Body:      "hello",
+			},
+			exp: []string{
+				"GET /rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments/10",
+				"PUT /rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments/10",
+			},
+		},
+		{
+			title: "create a commit comment",
+			cmt: &github.Comment{
+				Org:  "FOO",
+				Repo: "bar",
+				SHA1: "abc",
+				Body: "hello",
+			},
+			exp: []string{"POST /rest/api/1.0/projects/FOO/repos/bar/commits/abc/comments"},
+		},
+		{
+			title: "create a pull request comment if the comment can't be anchored",
+			cmt: &github.Comment{
+				Org:      "FOO",
+				Repo:     "bar",
+				PRNumber: 1,
+				Body:     "hello",
+				Path:     "main.go",
+				Line:     10,
+			},
+			handler: func() func(r *request) (int, string, http.Header) {
+				called := false
+				return func(_ *request) (int, string, http.Header) {
+					if !called {
+						called = true
+						return http.StatusConflict, `{"errors": [{"message": "The line is not in the diff"}]}`, nil
+					}
+					return http.StatusCreated, `{}`, nil
+				}
+			}(),
+			exp: []string{
+				"POST /rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments",
+				"POST /rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			handler := d.handler
+			if handler == nil {
+				handler = func(_ *request) (int, string, http.Header) {
+					return http.StatusOK, `{"id": 10, "version": 1}`, nil
+				}
+			}
+			client, requests := newTestClient(t, handler)
+			require.NoError(t, client.CreateComment(context.Background(), d.cmt))
+			paths := make([]string, len(*requests))
+			for i, r := range *requests {
+				paths[i] = r.Method + " " + r.Path
+			}
+			require.Equal(t, d.exp, paths)
+		})
+	}
+}
+
+func TestClient_ListComments(t *testing.T) {
+	t.Parallel()
+	client, _ := newTestClient(t, func(r *request) (int, string, http.Header) {
+		if r.Path == "/rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/activities?start=0&limit=100" {
+			return http.StatusOK, `{"isLastPage": false, "nextPageStart": 2, "values": [
+  {"action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 3, "text": "world", "author": {"name": "octocat"}, "createdDate": 0}},
+  {"action": "APPROVED"}
+]}`, nil
+		}
+		return http.StatusOK, `{"isLastPage": true, "values": [
+  {"action": "COMMENTED", "commentAction": "ADDED", "comment": {"id": 1, "text": "hello", "author": {"name": "octocat"}, "createdDate": 0}}
+]}`, nil
+	})
+	comments, err := client.ListComments(context.Background(), &github.PullRequest{
+		Org:      "FOO",
+		Repo:     "bar",
+		PRNumber: 1,
+	})
+	require.NoError(t, err)
+	exp := []*github.IssueComment{
+		{
+			ID:                "projects/FOO/repos/bar/pull-requests/1/comments/1",
+			DatabaseID:        1,
+			Kind:              github.CommentKindIssueComment,
+			Body:              "hello",
+			CreatedAt:         time.UnixMilli(0).UTC(),
+			ViewerCanMinimize: true,
+			ViewerCanDelete:   true,
+		},
+		{
+			ID:                "projects/FOO/repos/bar/pull-requests/1/comments/3",
+			DatabaseID:        3,
+			Kind:              github.CommentKindIssueComment,
+			Body:              "world",
+			CreatedAt:         time.UnixMilli(0).UTC(),
+			ViewerCanMinimize: true,
+			ViewerCanDelete:   true,
+		},
+	}
+	exp[0].Author.Login = "octocat"
+	exp[1].Author.Login = "octocat"
+	require.Equal(t, exp, comments)
+}
+
+func TestClient_HideComment(t *testing.T) {
+	t.Parallel()
+	client, requests := newTestClient(t, func(_ *request) (int, string, http.Header) {
+		return http.StatusOK, `{"id": 1, "version": 2}`, nil
+	})
+	require.NoError(t, client.HideComment(context.Background(), "projects/FOO/repos/bar/pull-requests/1/comments/1", ""))
+	require.Equal(t, []*request{
+		{Method: http.MethodGet, Path: "/rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments/1"},
+		{Method: http.MethodDelete, Path: "/rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/comments/1?version=2"},
+	}, *requests)
+}
+
+func TestClient_PRNumberWithSHA(t *testing.T) {
+	t.Parallel()
+	client, _ := newTestClient(t, func(_ *request) (int, string, http.Header) {
+		return http.StatusOK, `{"isLastPage": true, "values": [{"id": 1, "state": "MERGED"}, {"id": 2, "state": "OPEN"}]}`, nil
+	})
+	id, err := client.PRNumberWithSHA(context.Background(), "FOO", "bar", "abc")
+	require.NoError(t, err)
+	require.Equal(t, 2, id)
+}
+
+func TestClient_GetAuthenticatedUser(t *testing.T) {
+	t.Parallel()
+	client, _ := newTestClient(t, func(_ *request) (int, string, http.Header) {
+		return http.StatusOK, `{}`, http.Header{"X-Ausername": []string{"octocat"}}
+	})
+	name, err := client.GetAuthenticatedUser(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "octocat", name)
+}
@@ -0,0 +1,196 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/rest"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// maxCommentLength is the maximum length of a comment text.
+const maxCommentLength = 32768
+
+// listActivitiesLimit is the page size of listing pull request activities.
+const listActivitiesLimit = 100
+
+type comment struct {
+	ID      int64  `json:"id"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+	Author  struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	// CreatedDate is the unix time in milliseconds.
+	CreatedDate int64 `json:"createdDate"`
+}
+
+type activity struct {
+	Action        string   `json:"action"`
+	CommentAction string   `json:"commentAction"`
+	Comment       *comment `json:"comment"`
+}
+
+type anchor struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	LineType string `json:"lineType"`
+	FileType string `json:"fileType"`
+	DiffType string `json:"diffType"`
+}
+
+func pullRequestPath(org, repo string, prID int) string {
+	return repoPath(org, repo) + "/pull-requests/" + strconv.Itoa(prID)
+}
+
+// commentsPath returns the API path of comments of the pull request or the commit.
+func commentsPath(cmt *github.Comment) string {
+	if cmt.PRNumber != 0 {
+		return pullRequestPath(cmt.Org, cmt.Repo, cmt.PRNumber) + "/comments"
+	}
+	return repoPath(cmt.Org, cmt.Repo) + "/commits/" + cmt.SHA1 + "/comments"
+}
+
+// CreateComment creates or updates a comment of the pull request or the commit.
+// Bitbucket Server requires the version of the comment to update it, so the comment is got before it is updated.
+// If Path is set, the comment is anchored to the file line.
+// If the line isn't in the diff, the comment is posted to the pull request.
+func (c *Client) CreateComment(ctx context.Context, cmt *github.Comment) error {
+	body := cmt.Body
+	if len(body) > maxCommentLength {
+		c.logger.Warn("body is too long so it is replaced with `BodyForTooLong`",
+			"body_length", len(body),
+		)
+		body = cmt.BodyForTooLong
+	}
+	if cmt.CommentID != 0 {
+		p := commentsPath(cmt) + "/" + strconv.FormatInt(cmt.CommentID, 10)
+		current, err := c.getComment(ctx, p)
+		if err != nil {
+			return err
+		}
+		if _, err := c.rest.Do(ctx, http.MethodPut, p, map[string]any{
+			"text":    body,
+			"version": current.Version,
+		}, nil); err != nil {
+			return fmt.Errorf("update a comment by Bitbucket Server API: %w", err)
+		}
+		return nil
+	}
+	if cmt.PRNumber != 0 && cmt.Path != "" {
+		err := c.createAnchoredComment(ctx, cmt, body)
+		if err == nil || !rest.IsStatus(err, http.StatusBadRequest) && !rest.IsStatus(err, http.StatusConflict) {
+			return err
+		}
+		slogerr.WithError(c.logger, err).Warn("post the comment to the pull request as the comment can't be anchored to the file line",
+			"path", cmt.Path,
+			"line", cmt.Line,
+		)
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPost, commentsPath(cmt), map[string]string{
+		"text": body,
+	}, nil); err != nil {
+		return fmt.Errorf("create a comment by Bitbucket Server API: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) createAnchoredComment(ctx context.Context, cmt *github.Comment, body string) error {
+	a := &anchor{
+		Path:     cmt.Path,
+		Line:     cmt.Line,
+		LineType: "ADDED",
+		FileType: "TO",
+		DiffType: "EFFECTIVE",
+	}
+	if cmt.Side == "LEFT" {
+		a.LineType = "REMOVED"
+		a.FileType = "FROM"
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPost, commentsPath(cmt), map[string]any{
+		"text":   body,
+		"anchor": a,
+	}, nil); err != nil {
+		return fmt.Errorf("create a comment anchored to the file line by Bitbucket Server API: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) getComment(ctx context.Context, path string) (*comment, error) {
+	cmt := &comment{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, path, nil, cmt); err != nil {
+		return nil, fmt.Errorf("get a comment by Bitbucket Server API: %w", err)
+	}
+	return cmt, nil
+}
+
+// ListComments lists comments of the pull request from the pull request activities.
+// The ID of each comment is the API path of the comment.
+// Replies aren't included.
+func (c *Client) ListComments(ctx context.Context, pr *github.PullRequest) ([]*github.IssueComment, error) {
+	prPath := pullRequestPath(pr.Org, pr.Repo, pr.PRNumber)
+	comments := []*github.IssueComment{}
+	for start := 0; ; {
+		activities := &page[*activity]{}
+		if _, err := c.rest.Do(ctx, http.MethodGet, fmt.Sprintf("%s/activities?start=%d&limit=%d", prPath, start, listActivitiesLimit), nil, activities); err != nil {
+			return nil, fmt.Errorf("list pull request activities by Bitbucket Server API: %w", err)
+		}
+		for _, a := range activities.Values {
+			if a.Action != "COMMENTED" || a.CommentAction != "ADDED" || a.Comment == nil {
+				continue
+			}
+			comments = append(comments, convertComment(prPath, a.Comment))
+		}
+		if activities.IsLastPage {
+			break
+		}
+		start = activities.NextPageStart
+	}
+	// activities are sorted in the descending order of the creation time
+	for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+		comments[i], comments[j] = comments[j], comments[i]
+	}
+	return comments, nil
+}
+
+func convertComment(prPath string, cmt *comment) *github.IssueComment {
+	ic := &github.IssueComment{
+		ID:                prPath + "/comments/" + strconv.FormatInt(cmt.ID, 10),
+		DatabaseID:        cmt.ID,
+		Kind:              github.CommentKindIssueComment,
+		Body:              cmt.Text,
+		CreatedAt:         time.UnixMilli(cmt.CreatedDate).UTC(),
+		ViewerCanMinimize: true,
+		ViewerCanDelete:   true,
+	}
+	ic.Author.Login = cmt.Author.Name
+	return ic
+}
+
+// HideComment deletes the comment because Bitbucket Server doesn't support minimizing comments.
+// nodeID is the API path of the comment returned by ListComments.
+// classifier is ignored.
+func (c *Client) HideComment(ctx context.Context, nodeID, classifier string) error {
+	return c.deleteComment(ctx, nodeID)
+}
+
+func (c *Client) DeleteComment(ctx context.Context, pr *github.PullRequest, commentID int64) error {
+	return c.deleteComment(ctx, pullRequestPath(pr.Org, pr.Repo, pr.PRNumber)+"/comments/"+strconv.FormatInt(commentID, 10))
+}
+
+// deleteComment deletes the comment.
+// Bitbucket Server requires the version of the comment to delete it, so the comment is got before it is deleted.
+func (c *Client) deleteComment(ctx context.Context, path string) error {
+	cmt, err := c.getComment(ctx, path)
+	if err != nil {
+		return err
+	}
+	if _, err := c.rest.Do(ctx, http.MethodDelete, path+"?version="+strconv.Itoa(cmt.Version), nil, nil); err != nil {
+		return fmt.Errorf("delete a comment by Bitbucket Server API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PRNumberWithSHA returns the id of the pull request associated with the commit.
+// Open pull requests take precedence over others.
+// If no pull request is found, 0 is returned.
+func (c *Client) PRNumberWithSHA(ctx context.Context, owner, repo, sha string) (int, error) {
+	prs := &page[struct {
+		ID    int    `json:"id"`
+		State string `json:"state"`
+	}]{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, repoPath(owner, repo)+"/commits/"+sha+"/pull-requests", nil, prs); err != nil {
+		return 0, fmt.Errorf("list pull requests associated with a commit by Bitbucket Server API: %w", err)
+	}
+	for _, pr := range prs.Values {
+		if pr.State == "OPEN" {
+			return pr.ID, nil
+		}
+	}
+	if len(prs.Values) == 0 {
+		return 0, nil
+	}
+	return prs.Values[0].ID, nil
+}
+
+// GetAuthenticatedUser returns the name of the authenticated user.
+// Bitbucket Server doesn't have an API to get the authenticated user,
+// but returns the user name with the response header X-AUSERNAME.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (string, error) {
+	resp, err := c.rest.Do(ctx, http.MethodGet, "application-properties", nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("get application properties by Bitbucket Server API: %w", err)
+	}
+	name := resp.Header.Get("X-AUSERNAME")
+	if name == "" {
+		return "", errors.New("the response header X-AUSERNAME is empty")
+	}
+	return name, nil
+}
@@ -0,0 +1,11 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+)
+
+// UnhideComment isn't supported because HideComment deletes the comment.
+func (c *Client) UnhideComment(ctx context.Context, nodeID string) error {
+	return errors.New("comments can't be unhidden in Bitbucket Server because hidden comments are deleted")
+}
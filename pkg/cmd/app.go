@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/controller"
 	"github.com/suzuki-shunsuke/slog-util/slogutil"
 	"github.com/suzuki-shunsuke/urfave-cli-v3-util/urfave"
 	"github.com/urfave/cli/v3"
@@ -20,6 +21,8 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 	postArgs := &PostArgs{GlobalFlags: globalFlags}
 	execArgs := &ExecArgs{GlobalFlags: globalFlags}
 	hideArgs := &HideArgs{GlobalFlags: globalFlags}
+	unhideArgs := &HideArgs{GlobalFlags: globalFlags}
+	deleteArgs := &DeleteArgs{GlobalFlags: globalFlags}
 
 	return urfave.Command(env, &cli.Command{ //nolint:wrapcheck
 		Name:  "github-comment",
@@ -90,6 +93,21 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 						Usage:       "template variable name and file path",
 						Destination: &postArgs.VarFiles,
 					},
+					&cli.StringSliceFlag{
+						Name:        "var-json",
+						Usage:       "template variable name and JSON file path",
+						Destination: &postArgs.VarJSONFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "var-yaml",
+						Usage:       "template variable name and YAML file path",
+						Destination: &postArgs.VarYAMLFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "sarif-file",
+						Usage:       "template variable name and SARIF file path",
+						Destination: &postArgs.SARIFFiles,
+					},
 					&cli.BoolFlag{
 						Name:        "dry-run",
 						Usage:       "output a comment to standard error output instead of posting to GitHub",
@@ -198,6 +216,21 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 						Usage:       "template variable name and file path",
 						Destination: &execArgs.VarFiles,
 					},
+					&cli.StringSliceFlag{
+						Name:        "var-json",
+						Usage:       "template variable name and JSON file path",
+						Destination: &execArgs.VarJSONFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "var-yaml",
+						Usage:       "template variable name and YAML file path",
+						Destination: &execArgs.VarYAMLFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "sarif-file",
+						Usage:       "template variable name and SARIF file path",
+						Destination: &execArgs.SARIFFiles,
+					},
 					&cli.BoolFlag{
 						Name:        "dry-run",
 						Usage:       "output a comment to standard error output instead of posting to GitHub",
@@ -216,6 +249,28 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 						Usage:       "suppress the output of dry-run and skip-no-token",
 						Destination: &execArgs.Silent,
 					},
+					&cli.StringFlag{
+						Name:        "update-condition",
+						Aliases:     []string{"u"},
+						Usage:       "update the comment that matches with the condition",
+						Destination: &execArgs.UpdateCondition,
+					},
+					&cli.BoolFlag{
+						Name:        "progress",
+						Usage:       "post a comment before the command is run and update it while the command is running",
+						Destination: &execArgs.Progress,
+					},
+					&cli.DurationFlag{
+						Name:        "progress-interval",
+						Usage:       "the interval to update the progress comment",
+						Value:       controller.DefaultProgressInterval,
+						Destination: &execArgs.ProgressInterval,
+					},
+					&cli.DurationFlag{
+						Name:        "timeout",
+						Usage:       "the timeout of the command. SIGINT is sent to the command when it times out, and SIGKILL is sent if it doesn't exit in 30 seconds",
+						Destination: &execArgs.Timeout,
+					},
 				},
 			},
 			{
@@ -229,81 +284,112 @@ func Run(ctx context.Context, logger *slogutil.Logger, env *urfave.Env) error {
 				Action: func(ctx context.Context, _ *cli.Command) error {
 					return r.hideAction(ctx, logger, hideArgs)
 				},
+				Flags: hideFlags(hideArgs),
+			},
+			{
+				Name:  "unhide",
+				Usage: "unhide issue or pull request comments hidden by the hide command",
+				Action: func(ctx context.Context, _ *cli.Command) error {
+					return r.unhideAction(ctx, logger, unhideArgs)
+				},
+				Flags: hideFlags(unhideArgs),
+			},
+			{
+				Name:  "delete",
+				Usage: "delete issue or pull request comments",
+				Action: func(ctx context.Context, _ *cli.Command) error {
+					return r.deleteAction(ctx, logger, deleteArgs)
+				},
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:        "org",
 						Usage:       "GitHub organization name",
 						Sources:     cli.EnvVars("GH_COMMENT_REPO_ORG"),
-						Destination: &hideArgs.Org,
+						Destination: &deleteArgs.Org,
 					},
 					&cli.StringFlag{
 						Name:        "repo",
 						Usage:       "GitHub repository name",
 						Sources:     cli.EnvVars("GH_COMMENT_REPO_NAME"),
-						Destination: &hideArgs.Repo,
+						Destination: &deleteArgs.Repo,
 					},
 					&cli.StringFlag{
 						Name:        "token",
 						Usage:       "GitHub API token",
 						Sources:     cli.EnvVars("GITHUB_TOKEN", "GITHUB_ACCESS_TOKEN"),
-						Destination: &hideArgs.Token,
+						Destination: &deleteArgs.Token,
 					},
 					&cli.StringFlag{
 						Name:        "config",
 						Usage:       "configuration file path",
 						Sources:     cli.EnvVars("GH_COMMENT_CONFIG"),
-						Destination: &hideArgs.ConfigPath,
+						Destination: &deleteArgs.ConfigPath,
 					},
 					&cli.StringFlag{
 						Name:        "condition",
-						Usage:       "hide condition",
-						Destination: &hideArgs.Condition,
+						Usage:       "delete condition",
+						Destination: &deleteArgs.Condition,
 					},
 					&cli.StringFlag{
-						Name:        "hide-key",
+						Name:        "delete-key",
 						Aliases:     []string{"k"},
-						Usage:       "hide condition key",
+						Usage:       "delete condition key",
 						Value:       "default",
-						Destination: &hideArgs.HideKey,
+						Destination: &deleteArgs.DeleteKey,
 					},
 					&cli.IntFlag{
 						Name:        "pr",
 						Usage:       "GitHub pull request number",
 						Sources:     cli.EnvVars("GH_COMMENT_PR_NUMBER"),
-						Destination: &hideArgs.PRNumber,
+						Destination: &deleteArgs.PRNumber,
 					},
 					&cli.StringFlag{
 						Name:        "sha1",
 						Usage:       "commit sha1",
-						Destination: &hideArgs.SHA1,
+						Destination: &deleteArgs.SHA1,
 					},
 					&cli.StringSliceFlag{
 						Name:        "var",
 						Usage:       "template variable",
-						Destination: &hideArgs.Vars,
+						Destination: &deleteArgs.Vars,
 					},
 					&cli.StringSliceFlag{
 						Name:        "var-file",
 						Usage:       "template variable name and file path",
-						Destination: &hideArgs.VarFiles,
+						Destination: &deleteArgs.VarFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "var-json",
+						Usage:       "template variable name and JSON file path",
+						Destination: &deleteArgs.VarJSONFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "var-yaml",
+						Usage:       "template variable name and YAML file path",
+						Destination: &deleteArgs.VarYAMLFiles,
+					},
+					&cli.StringSliceFlag{
+						Name:        "sarif-file",
+						Usage:       "template variable name and SARIF file path",
+						Destination: &deleteArgs.SARIFFiles,
 					},
 					&cli.BoolFlag{
 						Name:        "dry-run",
-						Usage:       "output a comment to standard error output instead of posting to GitHub",
-						Destination: &hideArgs.DryRun,
+						Usage:       "output deleted comments to standard error output instead of deleting them",
+						Destination: &deleteArgs.DryRun,
 					},
 					&cli.BoolFlag{
 						Name:        "skip-no-token",
 						Aliases:     []string{"n"},
 						Usage:       "works like dry-run if the GitHub Access Token isn't set",
 						Sources:     cli.EnvVars("GH_COMMENT_SKIP_NO_TOKEN", "GITHUB_COMMENT_SKIP_NO_TOKEN"),
-						Destination: &hideArgs.SkipNoToken,
+						Destination: &deleteArgs.SkipNoToken,
 					},
 					&cli.BoolFlag{
 						Name:        "silent",
 						Aliases:     []string{"s"},
 						Usage:       "suppress the output of dry-run and skip-no-token",
-						Destination: &hideArgs.Silent,
+						Destination: &deleteArgs.Silent,
 					},
 				},
 			},
@@ -324,3 +410,104 @@ type Runner struct {
 	Stdout io.Writer
 	Stderr io.Writer
 }
+
+// hideFlags returns flags shared by the hide and unhide commands.
+func hideFlags(args *HideArgs) []cli.Flag { //nolint:funlen
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "org",
+			Usage:       "GitHub organization name",
+			Sources:     cli.EnvVars("GH_COMMENT_REPO_ORG"),
+			Destination: &args.Org,
+		},
+		&cli.StringFlag{
+			Name:        "repo",
+			Usage:       "GitHub repository name",
+			Sources:     cli.EnvVars("GH_COMMENT_REPO_NAME"),
+			Destination: &args.Repo,
+		},
+		&cli.StringFlag{
+			Name:        "token",
+			Usage:       "GitHub API token",
+			Sources:     cli.EnvVars("GITHUB_TOKEN", "GITHUB_ACCESS_TOKEN"),
+			Destination: &args.Token,
+		},
+		&cli.StringFlag{
+			Name:        "config",
+			Usage:       "configuration file path",
+			Sources:     cli.EnvVars("GH_COMMENT_CONFIG"),
+			Destination: &args.ConfigPath,
+		},
+		&cli.StringFlag{
+			Name:        "condition",
+			Usage:       "hide condition",
+			Destination: &args.Condition,
+		},
+		&cli.StringFlag{
+			Name:        "hide-key",
+			Aliases:     []string{"k"},
+			Usage:       "hide condition key",
+			Value:       "default",
+			Destination: &args.HideKey,
+		},
+		&cli.IntFlag{
+			Name:        "pr",
+			Usage:       "GitHub pull request number",
+			Sources:     cli.EnvVars("GH_COMMENT_PR_NUMBER"),
+			Destination: &args.PRNumber,
+		},
+		&cli.StringFlag{
+			Name:        "sha1",
+			Usage:       "commit sha1",
+			Destination: &args.SHA1,
+		},
+		&cli.IntFlag{
+			Name:        "parallelism",
+			Usage:       "the maximum number of comments processed concurrently",
+			Destination: &args.Parallelism,
+		},
+		&cli.StringSliceFlag{
+			Name:        "var",
+			Usage:       "template variable",
+			Destination: &args.Vars,
+		},
+		&cli.StringSliceFlag{
+			Name:        "var-file",
+			Usage:       "template variable name and file path",
+			Destination: &args.VarFiles,
+		},
+		&cli.StringSliceFlag{
+			Name:        "var-json",
+			Usage:       "template variable name and JSON file path",
+			Destination: &args.VarJSONFiles,
+		},
+		&cli.StringSliceFlag{
+			Name:        "var-yaml",
+			Usage:       "template variable name and YAML file path",
+			Destination: &args.VarYAMLFiles,
+		},
+		&cli.StringSliceFlag{
+			Name:        "sarif-file",
+			Usage:       "template variable name and SARIF file path",
+			Destination: &args.SARIFFiles,
+		},
+		&cli.BoolFlag{
+			Name:        "dry-run",
+			Usage:       "output a comment to standard error output instead of posting to GitHub",
+			Destination: &args.DryRun,
+		},
+		&cli.BoolFlag{
+			Name:        "skip-no-token",
+			Aliases:     []string{"n"},
+			Usage:       "works like dry-run if the GitHub Access Token isn't set",
+			Sources:     cli.EnvVars("GH_COMMENT_SKIP_NO_TOKEN", "GITHUB_COMMENT_SKIP_NO_TOKEN"),
+			Destination: &args.SkipNoToken,
+		},
+		&cli.BoolFlag{
+			Name:        "silent",
+			Aliases:     []string{"s"},
+			Usage:       "suppress the output of dry-run and skip-no-token",
+			Destination: &args.Silent,
+		},
+	}
+}
@@ -1,13 +1,25 @@
 package cmd
 
+import "time"
+
 // GlobalFlags holds flags shared across all commands.
 type GlobalFlags struct {
 	LogLevel string
 }
 
+// VarArgs holds flags to pass template variables.
+type VarArgs struct {
+	Vars         []string
+	VarFiles     []string
+	VarJSONFiles []string
+	VarYAMLFiles []string
+	SARIFFiles   []string
+}
+
 // PostArgs holds flags for the post command.
 type PostArgs struct {
 	*GlobalFlags
+	VarArgs
 
 	Org             string
 	Repo            string
@@ -17,8 +29,6 @@ type PostArgs struct {
 	TemplateKey     string
 	ConfigPath      string
 	PRNumber        int
-	Vars            []string
-	VarFiles        []string
 	DryRun          bool
 	SkipNoToken     bool
 	Silent          bool
@@ -29,38 +39,60 @@ type PostArgs struct {
 // ExecArgs holds flags for the exec command.
 type ExecArgs struct {
 	*GlobalFlags
+	VarArgs
+
+	Org             string
+	Repo            string
+	Token           string
+	SHA1            string
+	Template        string
+	TemplateKey     string
+	ConfigPath      string
+	PRNumber        int
+	Outputs         []string
+	DryRun          bool
+	SkipNoToken     bool
+	Silent          bool
+	UpdateCondition string
+	Args            []string
+	// Progress is true if the progress comment is posted while the command is running
+	Progress         bool
+	ProgressInterval time.Duration
+	Timeout          time.Duration
+}
+
+// HideArgs holds flags for the hide command.
+type HideArgs struct {
+	*GlobalFlags
+	VarArgs
 
 	Org         string
 	Repo        string
 	Token       string
-	SHA1        string
-	Template    string
-	TemplateKey string
 	ConfigPath  string
+	Condition   string
+	HideKey     string
 	PRNumber    int
-	Outputs     []string
-	Vars        []string
-	VarFiles    []string
+	SHA1        string
+	Parallelism int
 	DryRun      bool
 	SkipNoToken bool
 	Silent      bool
-	Args        []string
 }
 
-// HideArgs holds flags for the hide command.
-type HideArgs struct {
+// DeleteArgs holds flags for the delete command.
+type DeleteArgs struct {
 	*GlobalFlags
+	VarArgs
 
 	Org         string
 	Repo        string
 	Token       string
 	ConfigPath  string
 	Condition   string
-	HideKey     string
+	DeleteKey   string
 	PRNumber    int
 	SHA1        string
-	Vars        []string
-	VarFiles    []string
 	DryRun      bool
 	SkipNoToken bool
 	Silent      bool
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/controller"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/platform"
+	"github.com/suzuki-shunsuke/slog-util/slogutil"
+)
+
+// deleteAction is an entrypoint of the subcommand "delete".
+func (r *Runner) deleteAction(ctx context.Context, logger *slogutil.Logger, args *DeleteArgs) error { //nolint:funlen
+	if a := os.Getenv("GITHUB_COMMENT_SKIP"); a != "" {
+		skipComment, err := strconv.ParseBool(a)
+		if err != nil {
+			return fmt.Errorf("parse the environment variable GITHUB_COMMENT_SKIP as a bool: %w", err)
+		}
+		if skipComment {
+			return nil
+		}
+	}
+
+	vars, err := parseVars(&args.VarArgs)
+	if err != nil {
+		return err
+	}
+
+	opts := &option.DeleteOptions{
+		Options: option.Options{
+			PRNumber:    args.PRNumber,
+			Org:         args.Org,
+			Repo:        args.Repo,
+			Token:       args.Token,
+			SHA1:        args.SHA1,
+			ConfigPath:  args.ConfigPath,
+			LogLevel:    args.LogLevel,
+			Vars:        vars,
+			DryRun:      args.DryRun,
+			SkipNoToken: args.SkipNoToken,
+			Silent:      args.Silent,
+		},
+		DeleteKey: args.DeleteKey,
+		Condition: args.Condition,
+	}
+
+	if err := logger.SetLevel(opts.LogLevel); err != nil {
+		return fmt.Errorf("set log level: %w", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get a current directory path: %w", err)
+	}
+
+	cfgReader := config.Reader{
+		ExistFile: existFile,
+	}
+
+	cfg, err := cfgReader.FindAndRead(opts.ConfigPath, wd)
+	if err != nil {
+		return fmt.Errorf("find and read a configuration file: %w", err)
+	}
+	opts.SkipNoToken = opts.SkipNoToken || cfg.SkipNoToken
+	opts.Silent = opts.Silent || cfg.Silent
+
+	var pt controller.Platform = platform.Get()
+
+	gh, err := getGitHub(ctx, logger.Logger, &opts.Options, cfg)
+	if err != nil {
+		return fmt.Errorf("initialize commenter: %w", err)
+	}
+	if opts.DryRun {
+		// In dry-run mode, comments are listed by the real client so that comments which would be deleted are shown.
+		lister, err := getLister(ctx, logger.Logger, &opts.Options, cfg)
+		if err != nil {
+			return fmt.Errorf("initialize commenter: %w", err)
+		}
+		gh = &dryRunDeleter{
+			GitHub: lister,
+			mock:   gh,
+		}
+	}
+
+	ctrl := controller.DeleteController{
+		Wd:       wd,
+		Getenv:   os.Getenv,
+		Stderr:   r.Stderr,
+		GitHub:   gh,
+		Platform: pt,
+		Config:   cfg,
+		Expr:     &expr.Expr{},
+	}
+	return ctrl.Delete(ctx, logger.Logger, opts) //nolint:wrapcheck
+}
+
+// dryRunDeleter lists comments by the real client but outputs comments to standard error output instead of deleting them.
+type dryRunDeleter struct {
+	controller.GitHub
+
+	mock controller.GitHub
+}
+
+func (d *dryRunDeleter) DeleteComment(ctx context.Context, pr *github.PullRequest, commentID int64) error {
+	return d.mock.DeleteComment(ctx, pr, commentID) //nolint:wrapcheck
+}
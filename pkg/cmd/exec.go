@@ -13,9 +13,11 @@ import (
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/controller"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/execute"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/platform"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/template"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/upload"
 	"github.com/suzuki-shunsuke/slog-util/slogutil"
 )
 
@@ -25,7 +27,7 @@ func existFile(p string) bool {
 }
 
 func (r *Runner) execAction(ctx context.Context, logger *slogutil.Logger, args *ExecArgs) error { //nolint:cyclop,funlen
-	vars, err := parseVars(args.Vars, args.VarFiles)
+	vars, err := parseVars(&args.VarArgs)
 	if err != nil {
 		return err
 	}
@@ -37,11 +39,19 @@ func (r *Runner) execAction(ctx context.Context, logger *slogutil.Logger, args *
 			outs[i] = &option.Output{GitHub: true}
 			continue
 		}
+		if o == "review" {
+			outs[i] = &option.Output{Review: true}
+			continue
+		}
 		if f, ok := strings.CutPrefix(o, "file:"); ok {
 			outs[i] = &option.Output{File: f}
 			continue
 		}
-		return errors.New("invalid the value of -out. -out must be either github or file:<file path>")
+		if name, ok := strings.CutPrefix(o, "check-run:"); ok && name != "" {
+			outs[i] = &option.Output{CheckRun: name}
+			continue
+		}
+		return errors.New("invalid the value of -out. -out must be either github, review, file:<file path>, or check-run:<name>")
 	}
 	if len(outputs) == 0 {
 		outs = []*option.Output{{GitHub: true}}
@@ -63,8 +73,12 @@ func (r *Runner) execAction(ctx context.Context, logger *slogutil.Logger, args *
 			SkipNoToken: args.SkipNoToken,
 			Silent:      args.Silent,
 		},
-		Args:    args.Args,
-		Outputs: outs,
+		Args:             args.Args,
+		Outputs:          outs,
+		UpdateCondition:  args.UpdateCondition,
+		Progress:         args.Progress,
+		ProgressInterval: args.ProgressInterval,
+		Timeout:          args.Timeout,
 	}
 
 	if a := os.Getenv("GITHUB_COMMENT_SKIP"); a != "" {
@@ -119,5 +133,12 @@ func (r *Runner) execAction(ctx context.Context, logger *slogutil.Logger, args *
 		Config:   cfg,
 		Fs:       afero.NewOsFs(),
 	}
+	// In dry-run mode, the output isn't uploaded
+	if _, isMock := gh.(*github.Mock); !isMock && cfg.OverflowUpload != nil && cfg.OverflowUpload.URL != "" {
+		ctrl.Uploader = upload.New(&upload.ParamNew{
+			URL:   cfg.OverflowUpload.URL,
+			Token: os.Getenv("GH_COMMENT_OVERFLOW_UPLOAD_TOKEN"),
+		})
+	}
 	return ctrl.Exec(ctx, logger.Logger, opts) //nolint:wrapcheck
 }
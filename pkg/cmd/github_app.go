@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+)
+
+// getGitHubApp returns the GitHub App to authenticate as.
+// The configuration file takes precedence over environment variables.
+// If the app id isn't set, nil is returned and an access token is used.
+func getGitHubApp(cfg *config.Config, opts *option.Options) (*github.App, error) {
+	appCfg := cfg.GitHubApp
+	if appCfg == nil {
+		appCfg = &config.GitHubApp{}
+	}
+	appID := appCfg.AppID
+	if appID == 0 {
+		s := os.Getenv("GH_COMMENT_APP_ID")
+		if s == "" {
+			return nil, nil //nolint:nilnil
+		}
+		a, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse the environment variable GH_COMMENT_APP_ID as an integer: %w", err)
+		}
+		appID = a
+	}
+	installationID := appCfg.InstallationID
+	if s := os.Getenv("GH_COMMENT_APP_INSTALLATION_ID"); installationID == 0 && s != "" {
+		a, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse the environment variable GH_COMMENT_APP_INSTALLATION_ID as an integer: %w", err)
+		}
+		installationID = a
+	}
+	key, err := readGitHubAppPrivateKey(appCfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	opts.GitHubApp = true
+	return &github.App{
+		AppID:          appID,
+		PrivateKey:     key,
+		InstallationID: installationID,
+		Repo: func() (string, string) {
+			// opts is complemented with the configuration file and CI's environment variables after the client is created
+			return opts.Org, opts.Repo
+		},
+	}, nil
+}
+
+// hasGitHubApp returns true if the app id of the GitHub App is set.
+func hasGitHubApp(cfg *config.Config) bool {
+	if cfg.GitHubApp != nil && cfg.GitHubApp.AppID != 0 {
+		return true
+	}
+	return os.Getenv("GH_COMMENT_APP_ID") != ""
+}
+
+func readGitHubAppPrivateKey(keyFile string) ([]byte, error) {
+	if keyFile == "" {
+		if key := os.Getenv("GH_COMMENT_APP_PRIVATE_KEY"); key != "" {
+			return []byte(key), nil
+		}
+		keyFile = os.Getenv("GH_COMMENT_APP_PRIVATE_KEY_FILE")
+	}
+	if keyFile == "" {
+		return nil, errors.New("the private key of the GitHub App is required")
+	}
+	b, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read the private key file of the GitHub App: %w", err)
+	}
+	return b, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 
@@ -16,7 +17,19 @@ import (
 )
 
 // hideAction is an entrypoint of the subcommand "hide".
-func (r *Runner) hideAction(ctx context.Context, logger *slogutil.Logger, args *HideArgs) error { //nolint:funlen
+func (r *Runner) hideAction(ctx context.Context, logger *slogutil.Logger, args *HideArgs) error {
+	return r.runHideController(ctx, logger, args, (*controller.HideController).Hide)
+}
+
+// unhideAction is an entrypoint of the subcommand "unhide".
+func (r *Runner) unhideAction(ctx context.Context, logger *slogutil.Logger, args *HideArgs) error {
+	return r.runHideController(ctx, logger, args, (*controller.HideController).Unhide)
+}
+
+type hideControllerAction func(ctrl *controller.HideController, ctx context.Context, logger *slog.Logger, opts *option.HideOptions) error
+
+// runHideController initializes HideController and runs the action.
+func (r *Runner) runHideController(ctx context.Context, logger *slogutil.Logger, args *HideArgs, action hideControllerAction) error { //nolint:funlen
 	if a := os.Getenv("GITHUB_COMMENT_SKIP"); a != "" {
 		skipComment, err := strconv.ParseBool(a)
 		if err != nil {
@@ -27,7 +40,7 @@ func (r *Runner) hideAction(ctx context.Context, logger *slogutil.Logger, args *
 		}
 	}
 
-	vars, err := parseVars(args.Vars, args.VarFiles)
+	vars, err := parseVars(&args.VarArgs)
 	if err != nil {
 		return err
 	}
@@ -46,8 +59,9 @@ func (r *Runner) hideAction(ctx context.Context, logger *slogutil.Logger, args *
 			SkipNoToken: args.SkipNoToken,
 			Silent:      args.Silent,
 		},
-		HideKey:   args.HideKey,
-		Condition: args.Condition,
+		HideKey:     args.HideKey,
+		Condition:   args.Condition,
+		Parallelism: args.Parallelism,
 	}
 
 	if err := logger.SetLevel(opts.LogLevel); err != nil {
@@ -87,5 +101,5 @@ func (r *Runner) hideAction(ctx context.Context, logger *slogutil.Logger, args *
 		Config:   cfg,
 		Expr:     &expr.Expr{},
 	}
-	return ctrl.Hide(ctx, logger.Logger, opts) //nolint:wrapcheck
+	return action(&ctrl, ctx, logger.Logger, opts)
 }
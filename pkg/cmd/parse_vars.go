@@ -1,22 +1,53 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
 	"os"
 	"strings"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/sarif"
+	"gopkg.in/yaml.v2"
+)
+
+// Environment variables to pass template variables.
+// GH_COMMENT_VAR_<name> is passed as a string.
+// GH_COMMENT_JSON_VAR_<name> and GH_COMMENT_YAML_VAR_<name> are decoded as JSON and YAML.
+const (
+	varEnvPrefix     = "GH_COMMENT_VAR_"
+	varJSONEnvPrefix = "GH_COMMENT_JSON_VAR_"
+	varYAMLEnvPrefix = "GH_COMMENT_YAML_VAR_"
 )
 
-func parseVarEnvs() map[string]string {
-	m := map[string]string{}
-	for _, kv := range os.Environ() {
+func parseVarEnvs(environ []string) (map[string]any, error) {
+	m := map[string]any{}
+	typed := map[string]any{}
+	for _, kv := range environ {
 		k, v, _ := strings.Cut(kv, "=")
-		if a, ok := strings.CutPrefix(k, "GH_COMMENT_VAR_"); ok {
+		if a, ok := strings.CutPrefix(k, varEnvPrefix); ok {
 			m[a] = v
+			continue
+		}
+		if a, ok := strings.CutPrefix(k, varJSONEnvPrefix); ok {
+			val, err := decodeJSON([]byte(v))
+			if err != nil {
+				return nil, fmt.Errorf("decode the environment variable %s as JSON: %w", k, err)
+			}
+			typed[a] = val
+			continue
+		}
+		if a, ok := strings.CutPrefix(k, varYAMLEnvPrefix); ok {
+			val, err := decodeYAML([]byte(v))
+			if err != nil {
+				return nil, fmt.Errorf("decode the environment variable %s as YAML: %w", k, err)
+			}
+			typed[a] = val
 		}
 	}
-	return m
+	maps.Copy(m, typed)
+	return m, nil
 }
 
 func parseVarsFlag(varsSlice []string) (map[string]string, error) {
@@ -49,17 +80,101 @@ func parseVarFilesFlag(varsSlice []string) (map[string]string, error) {
 	return vars, nil
 }
 
-func parseVars(vars, varFiles []string) (map[string]string, error) {
-	m := parseVarEnvs()
-	flagVars, err := parseVarsFlag(vars)
+// parseTypedVarFilesFlag reads files and decodes them by decode.
+// flagName is used in error messages.
+func parseTypedVarFilesFlag(varsSlice []string, flagName string, decode func([]byte) (any, error)) (map[string]any, error) {
+	vars := make(map[string]any, len(varsSlice))
+	for _, v := range varsSlice {
+		name, filePath, ok := strings.Cut(v, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s flag. The format should be '--%s <key>:<file path>", flagName, flagName)
+		}
+		b, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("read the value of the variable %s from the file %s: %w", name, filePath, err)
+		}
+		val, err := decode(b)
+		if err != nil {
+			return nil, fmt.Errorf("decode the value of the variable %s from the file %s: %w", name, filePath, err)
+		}
+		vars[name] = val
+	}
+	return vars, nil
+}
+
+func decodeJSON(b []byte) (any, error) {
+	var val any
+	if err := json.Unmarshal(b, &val); err != nil {
+		return nil, fmt.Errorf("decode JSON: %w", err)
+	}
+	return val, nil
+}
+
+func decodeYAML(b []byte) (any, error) {
+	var val any
+	if err := yaml.Unmarshal(b, &val); err != nil {
+		return nil, fmt.Errorf("decode YAML: %w", err)
+	}
+	return normalizeYAML(val), nil
+}
+
+// normalizeYAML converts map[any]any decoded by yaml.v2 to map[string]any
+// so that values can be accessed in templates and expressions and be encoded as JSON.
+func normalizeYAML(val any) any {
+	switch v := val.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, a := range v {
+			m[fmt.Sprint(k)] = normalizeYAML(a)
+		}
+		return m
+	case []any:
+		for i, a := range v {
+			v[i] = normalizeYAML(a)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func decodeSARIF(b []byte) (any, error) {
+	return sarif.Parse(b) //nolint:wrapcheck
+}
+
+func parseVars(args *VarArgs) (map[string]any, error) {
+	m, err := parseVarEnvs(os.Environ())
 	if err != nil {
 		return nil, err
 	}
-	maps.Copy(m, flagVars)
-	fileVars, err := parseVarFilesFlag(varFiles)
+	flagVars, err := parseVarsFlag(args.Vars)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range flagVars {
+		m[k] = v
+	}
+	fileVars, err := parseVarFilesFlag(args.VarFiles)
 	if err != nil {
 		return nil, err
 	}
-	maps.Copy(m, fileVars)
+	for k, v := range fileVars {
+		m[k] = v
+	}
+	for _, typed := range []struct {
+		files    []string
+		flagName string
+		decode   func([]byte) (any, error)
+	}{
+		{files: args.VarJSONFiles, flagName: "var-json", decode: decodeJSON},
+		{files: args.VarYAMLFiles, flagName: "var-yaml", decode: decodeYAML},
+		{files: args.SARIFFiles, flagName: "sarif-file", decode: decodeSARIF},
+	} {
+		vars, err := parseTypedVarFilesFlag(typed.files, typed.flagName, typed.decode)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(m, vars)
+	}
 	return m, nil
 }
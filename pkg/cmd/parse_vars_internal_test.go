@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/sarif"
+)
+
+func Test_parseVarEnvs(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title   string
+		environ []string
+		exp     map[string]any
+		isErr   bool
+	}{
+		{
+			title: "string",
+			environ: []string{
+				"GH_COMMENT_VAR_foo=bar",
+				"GH_COMMENT_VAR_empty=",
+				"HOME=/root",
+			},
+			exp: map[string]any{
+				"foo":   "bar",
+				"empty": "",
+			},
+		},
+		{
+			title: "json",
+			environ: []string{
+				`GH_COMMENT_JSON_VAR_targets=["foo","bar"]`,
+				`GH_COMMENT_JSON_VAR_count=3`,
+				`GH_COMMENT_JSON_VAR_obj={"enabled":true,"name":"foo=bar"}`,
+			},
+			exp: map[string]any{
+				"targets": []any{"foo", "bar"},
+				"count":   float64(3),
+				"obj": map[string]any{
+					"enabled": true,
+					"name":    "foo=bar",
+				},
+			},
+		},
+		{
+			title: "yaml",
+			environ: []string{
+				"GH_COMMENT_YAML_VAR_obj=name: foo\nitems:\n- a: 1\n  2: b",
+				"GH_COMMENT_YAML_VAR_list=[1, true]",
+			},
+			exp: map[string]any{
+				"obj": map[string]any{
+					"name": "foo",
+					"items": []any{
+						map[string]any{"a": 1, "2": "b"},
+					},
+				},
+				"list": []any{1, true},
+			},
+		},
+		{
+			title: "typed variables take precedence over string variables",
+			environ: []string{
+				`GH_COMMENT_JSON_VAR_foo=1`,
+				"GH_COMMENT_VAR_foo=bar",
+			},
+			exp: map[string]any{
+				"foo": float64(1),
+			},
+		},
+		{
+			title:   "invalid json",
+			environ: []string{`GH_COMMENT_JSON_VAR_foo={`},
+			isErr:   true,
+		},
+		{
+			title:   "invalid yaml",
+			environ: []string{"GH_COMMENT_YAML_VAR_foo=a: [b"},
+			isErr:   true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			m, err := parseVarEnvs(d.environ)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, m)
+		})
+	}
+}
+
+func Test_normalizeYAML(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		val   any
+		exp   any
+	}{
+		{
+			title: "scalar",
+			val:   "foo",
+			exp:   "foo",
+		},
+		{
+			title: "nested map",
+			val: map[any]any{
+				"foo": map[any]any{
+					1:    "one",
+					true: []any{map[any]any{"bar": nil}},
+				},
+			},
+			exp: map[string]any{
+				"foo": map[string]any{
+					"1":    "one",
+					"true": []any{map[string]any{"bar": nil}},
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, d.exp, normalizeYAML(d.val))
+		})
+	}
+}
+
+func Test_parseVars_sarifFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	p := filepath.Join(dir, "trivy.sarif")
+	require.NoError(t, os.WriteFile(p, []byte(`{"version": "2.1.0", "runs": [{"results": [{"ruleId": "CVE-1", "level": "error", "message": {"text": "vulnerable"}}]}]}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.sarif"), []byte(`{`), 0o600))
+
+	vars, err := parseTypedVarFilesFlag([]string{"trivy:" + p}, "sarif-file", decodeSARIF)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"trivy": &sarif.Report{
+			Results: []*sarif.Result{
+				{RuleID: "CVE-1", Level: "error", Message: "vulnerable"},
+			},
+		},
+	}, vars)
+
+	_, err = parseTypedVarFilesFlag([]string{"trivy:" + filepath.Join(dir, "invalid.sarif")}, "sarif-file", decodeSARIF)
+	require.Error(t, err)
+	_, err = parseTypedVarFilesFlag([]string{"trivy"}, "sarif-file", decodeSARIF)
+	require.Error(t, err)
+	_, err = parseTypedVarFilesFlag([]string{"trivy:" + filepath.Join(dir, "not-found.sarif")}, "sarif-file", decodeSARIF)
+	require.Error(t, err)
+}
@@ -2,34 +2,113 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/bitbucket"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/controller"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/gitea"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/gitlab"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/platform"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/template"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
 	"github.com/suzuki-shunsuke/slog-util/slogutil"
 	"golang.org/x/term"
 )
 
 func getGitHub(ctx context.Context, logger *slog.Logger, opts *option.Options, cfg *config.Config) (controller.GitHub, error) {
+	// The GitHub App isn't used in dry-run mode, so the private key isn't read
+	app, err := getCredential(logger, opts, cfg, !opts.DryRun)
+	if err != nil {
+		return nil, err
+	}
 	if opts.DryRun {
-		return &github.Mock{
-			Stderr: os.Stderr,
-			Silent: opts.Silent,
-		}, nil
+		return newMock(opts), nil
+	}
+	if opts.SkipNoToken && opts.Token == "" && app == nil {
+		return newMock(opts), nil
+	}
+	return newGitHub(ctx, logger, opts, cfg, app)
+}
+
+// getLister returns the client to list comments in dry-run mode.
+// Comments are listed by the real client so that comments which would be processed are shown.
+// If neither an access token nor a GitHub App is available, the mock is returned so that dry-run works without credentials.
+func getLister(ctx context.Context, logger *slog.Logger, opts *option.Options, cfg *config.Config) (controller.GitHub, error) {
+	app, err := getCredential(logger, opts, cfg, true)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Token == "" && app == nil {
+		return newMock(opts), nil
+	}
+	return newGitHub(ctx, logger, opts, cfg, app)
+}
+
+func newMock(opts *option.Options) *github.Mock {
+	return &github.Mock{
+		Stderr: os.Stderr,
+		Silent: opts.Silent,
+	}
+}
+
+// getCredential complements the access token with environment variables and returns the GitHub App.
+// If readApp is false, the GitHub App isn't read.
+func getCredential(logger *slog.Logger, opts *option.Options, cfg *config.Config, readApp bool) (*github.App, error) {
+	switch cfg.Forge {
+	case "", config.ForgeGitHub:
+		if !readApp {
+			opts.GitHubApp = hasGitHubApp(cfg)
+			return nil, nil //nolint:nilnil
+		}
+		app, err := getGitHubApp(cfg, opts)
+		if err != nil {
+			if !opts.SkipNoToken || opts.Token != "" {
+				return nil, err
+			}
+			slogerr.WithError(logger, err).Warn("the GitHub App isn't available, so comments aren't posted")
+		}
+		return app, nil
+	case config.ForgeGitLab:
+		if opts.Token == "" {
+			opts.Token = os.Getenv("GITLAB_TOKEN")
+		}
+	case config.ForgeGitea:
+		if opts.Token == "" {
+			opts.Token = os.Getenv("GITEA_TOKEN")
+		}
+	case config.ForgeBitbucketServer:
+		if opts.Token == "" {
+			opts.Token = os.Getenv("BITBUCKET_TOKEN")
+		}
+	default:
+		return nil, errors.New("invalid forge. forge must be either github, gitlab, gitea, or bitbucket-server: " + cfg.Forge)
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// newGitHub returns the client of the forge.
+func newGitHub(ctx context.Context, logger *slog.Logger, opts *option.Options, cfg *config.Config, app *github.App) (controller.GitHub, error) {
+	retry, err := getRetry(cfg.Retry)
+	if err != nil {
+		return nil, err
 	}
-	if opts.SkipNoToken && opts.Token == "" {
-		return &github.Mock{
-			Stderr: os.Stderr,
-			Silent: opts.Silent,
-		}, nil
+
+	switch cfg.Forge {
+	case config.ForgeGitLab:
+		return getGitLab(logger, opts, cfg, retry), nil
+	case config.ForgeGitea:
+		return getGitea(logger, opts, cfg, retry)
+	case config.ForgeBitbucketServer:
+		return getBitbucketServer(logger, opts, cfg, retry)
 	}
 
 	// https://github.com/suzuki-shunsuke/github-comment/issues/1489
@@ -44,10 +123,78 @@ func getGitHub(ctx context.Context, logger *slog.Logger, opts *option.Options, c
 		Token:              opts.Token,
 		GHEBaseURL:         cfg.GHEBaseURL,
 		GHEGraphQLEndpoint: cfg.GHEGraphQLEndpoint,
+		App:                app,
+		Retry:              retry,
 		Logger:             logger,
 	})
 }
 
+// getRetry returns the configuration to retry GitHub API requests.
+// If the configuration isn't set, default values are used.
+func getRetry(cfg *config.Retry) (*github.Retry, error) {
+	retry := &github.Retry{
+		MaxRetries: github.DefaultMaxRetries,
+		MaxWait:    github.DefaultMaxWait,
+	}
+	if cfg == nil {
+		return retry, nil
+	}
+	if cfg.MaxRetries != nil {
+		retry.MaxRetries = *cfg.MaxRetries
+	}
+	if cfg.MaxWait != "" {
+		d, err := time.ParseDuration(cfg.MaxWait)
+		if err != nil {
+			return nil, fmt.Errorf("parse retry.max_wait as a duration: %w", err)
+		}
+		retry.MaxWait = d
+	}
+	return retry, nil
+}
+
+func getGitLab(logger *slog.Logger, opts *option.Options, cfg *config.Config, retry *github.Retry) *gitlab.Client {
+	baseURL := ""
+	if cfg.GitLab != nil {
+		baseURL = cfg.GitLab.BaseURL
+	}
+	if baseURL == "" {
+		// GitLab CI sets the API URL
+		baseURL = os.Getenv("CI_API_V4_URL")
+	}
+	return gitlab.New(&gitlab.ParamNew{
+		Token:   opts.Token,
+		BaseURL: baseURL,
+		Retry:   retry,
+		Logger:  logger,
+	})
+}
+
+func getGitea(logger *slog.Logger, opts *option.Options, cfg *config.Config, retry *github.Retry) (*gitea.Client, error) {
+	baseURL := ""
+	if cfg.Gitea != nil {
+		baseURL = cfg.Gitea.BaseURL
+	}
+	return gitea.New(&gitea.ParamNew{ //nolint:wrapcheck
+		Token:   opts.Token,
+		BaseURL: baseURL,
+		Retry:   retry,
+		Logger:  logger,
+	})
+}
+
+func getBitbucketServer(logger *slog.Logger, opts *option.Options, cfg *config.Config, retry *github.Retry) (*bitbucket.Client, error) {
+	baseURL := ""
+	if cfg.BitbucketServer != nil {
+		baseURL = cfg.BitbucketServer.BaseURL
+	}
+	return bitbucket.New(&bitbucket.ParamNew{ //nolint:wrapcheck
+		Token:   opts.Token,
+		BaseURL: baseURL,
+		Retry:   retry,
+		Logger:  logger,
+	})
+}
+
 // postAction is an entrypoint of the subcommand "post".
 func (r *Runner) postAction(ctx context.Context, logger *slogutil.Logger, args *PostArgs) error { //nolint:funlen
 	if a := os.Getenv("GITHUB_COMMENT_SKIP"); a != "" {
@@ -60,7 +207,7 @@ func (r *Runner) postAction(ctx context.Context, logger *slogutil.Logger, args *
 		}
 	}
 
-	vars, err := parseVars(args.Vars, args.VarFiles)
+	vars, err := parseVars(&args.VarArgs)
 	if err != nil {
 		return err
 	}
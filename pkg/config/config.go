@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/invopop/jsonschema"
 )
@@ -10,15 +11,58 @@ type Config struct {
 	Base               *Base                    `json:"base,omitempty" jsonschema:"description=Repository where to post comments"`
 	GHEBaseURL         string                   `json:"ghe_base_url,omitempty" yaml:"ghe_base_url" jsonschema:"description=GitHub Enterprise Base URL"`
 	GHEGraphQLEndpoint string                   `json:"ghe_graphql_endpoint,omitempty" yaml:"ghe_graphql_endpoint" jsonschema:"description=GitHub Enterprise GraphQL Endpoint"`
+	GitHubApp          *GitHubApp               `json:"github_app,omitempty" yaml:"github_app" jsonschema:"description=GitHub App to authenticate as instead of a GitHub access token"`
+	Retry              *Retry                   `json:"retry,omitempty" jsonschema:"description=Retry API requests which fail due to server errors or rate limits"`
+	OverflowUpload     *OverflowUpload          `json:"overflow_upload,omitempty" yaml:"overflow_upload" jsonschema:"description=Upload a too long command output to the HTTP endpoint instead of a secret gist"`
+	Forge              string                   `json:"forge,omitempty" jsonschema:"description=Git hosting service. The default is github,enum=github,enum=gitlab,enum=gitea,enum=bitbucket-server"`
+	GitLab             *GitLab                  `json:"gitlab,omitempty" jsonschema:"description=GitLab configuration. This is used if forge is gitlab"`
+	Gitea              *Gitea                   `json:"gitea,omitempty" jsonschema:"description=Gitea configuration. This is used if forge is gitea"`
+	BitbucketServer    *BitbucketServer         `json:"bitbucket_server,omitempty" yaml:"bitbucket_server" jsonschema:"description=Bitbucket Server configuration. This is used if forge is bitbucket-server"`
 	Vars               map[string]any           `json:"vars,omitempty" jsonschema:"description=variables to pass to templates"`
 	Templates          map[string]string        `json:"templates,omitempty" jsonschema:"description=templates"`
 	Post               map[string]*PostConfig   `json:"post,omitempty" jsonschema:"description=configuration for github-comment post command"`
 	Exec               map[string][]*ExecConfig `json:"exec,omitempty" jsonschema:"description=configuration for github-comment exec command"`
-	Hide               map[string]string        `json:"hide,omitempty" jsonschema:"description=configuration for github-comment hide command"`
+	Hide               map[string]*HideConfig   `json:"hide,omitempty" jsonschema:"description=configuration for github-comment hide command"`
+	HideParallelism    int                      `json:"hide_parallelism,omitempty" yaml:"hide_parallelism" jsonschema:"description=The maximum number of comments hidden concurrently. The default is 4"`
+	Delete             map[string]string        `json:"delete,omitempty" jsonschema:"description=configuration for github-comment delete command"`
 	SkipNoToken        bool                     `json:"skip_no_token,omitempty" yaml:"skip_no_token" jsonschema:"description=Skip to post comments if no GitHub access token is passed"`
 	Silent             bool                     `json:"silent,omitempty"`
 }
 
+const (
+	ForgeGitHub          = "github"
+	ForgeGitLab          = "gitlab"
+	ForgeGitea           = "gitea"
+	ForgeBitbucketServer = "bitbucket-server"
+)
+
+type GitHubApp struct {
+	AppID          int64  `json:"app_id,omitempty" yaml:"app_id" jsonschema:"description=GitHub App ID. The environment variable GH_COMMENT_APP_ID is also available"`
+	InstallationID int64  `json:"installation_id,omitempty" yaml:"installation_id" jsonschema:"description=GitHub App installation ID. If this isn't set the installation is found from the repository"`
+	PrivateKeyFile string `json:"private_key_file,omitempty" yaml:"private_key_file" jsonschema:"description=GitHub App private key file path. The environment variables GH_COMMENT_APP_PRIVATE_KEY and GH_COMMENT_APP_PRIVATE_KEY_FILE are also available"`
+}
+
+type Retry struct {
+	MaxRetries *int   `json:"max_retries,omitempty" yaml:"max_retries" jsonschema:"description=The maximum number of retries. The default is 3. If this is 0 requests aren't retried"`
+	MaxWait    string `json:"max_wait,omitempty" yaml:"max_wait" jsonschema:"description=The maximum wait time before retrying a request. The format is Go's time.Duration. The default is 1m"`
+}
+
+type OverflowUpload struct {
+	URL string `json:"url" jsonschema:"description=The endpoint where a too long command output is uploaded by a POST request. The endpoint must return the URL of the uploaded output in the response header Location"`
+}
+
+type GitLab struct {
+	BaseURL string `json:"base_url,omitempty" yaml:"base_url" jsonschema:"description=GitLab API base URL. e.g. https://gitlab.example.com/api/v4"`
+}
+
+type Gitea struct {
+	BaseURL string `json:"base_url" yaml:"base_url" jsonschema:"description=Gitea API base URL. e.g. https://gitea.example.com/api/v1"`
+}
+
+type BitbucketServer struct {
+	BaseURL string `json:"base_url" yaml:"base_url" jsonschema:"description=Bitbucket Server REST API base URL. e.g. https://bitbucket.example.com/rest/api/1.0"`
+}
+
 type Base struct {
 	Org  string `json:"org,omitempty" jsonschema:"description=GitHub organization name"`
 	Repo string `json:"repo,omitempty" jsonschema:"description=GitHub repository name"`
@@ -32,6 +76,85 @@ type PostConfig struct { //nolint:recvcheck
 	// If multiple comments match, the latest comment is updated.
 	// If no comment matches, a new comment is created.
 	UpdateCondition string `json:"update,omitempty" jsonschema:"description=Update comments that matches with the condition"`
+	// ReviewComment posts the comment to the file line of the pull request as a review comment.
+	// If the line isn't in the diff, the comment is posted to the pull request conversation.
+	ReviewComment *ReviewCommentConfig `json:"review_comment,omitempty" jsonschema:"description=Post the comment to the file line of the pull request"`
+	// Overflow is the way to post a too long comment.
+	// If Overflow is empty, template_for_too_long is posted.
+	Overflow string `json:"overflow,omitempty" jsonschema:"description=How to post a too long comment. By default template_for_too_long is posted,enum=split"`
+	// HidePrevious hides previous comments after the comment is posted.
+	// HidePrevious is either a key of hide or a condition.
+	HidePrevious string `json:"hide_previous,omitempty" jsonschema:"description=Hide previous comments after the comment is posted. A key of hide or a condition"`
+}
+
+const (
+	// OverflowSplit splits a too long comment into multiple comments.
+	OverflowSplit = "split"
+	// OverflowGist uploads a too long command output to a secret gist.
+	OverflowGist = "gist"
+)
+
+// HideConfig is the configuration of the hide command.
+// HideConfig can be a string, which is the condition.
+type HideConfig struct {
+	// Condition selects comments to hide.
+	Condition string `json:"condition" jsonschema:"description=Hide comments that match with the condition"`
+	// GroupBy is an expression to group comments selected by Condition.
+	// The result of the expression is the key of the group.
+	GroupBy string `json:"group_by,omitempty" yaml:"group_by" jsonschema:"description=Group comments by the expression. The result of the expression is the key of the group"`
+	// KeepLatest is the number of the latest comments kept in each group.
+	// If KeepLatest is 0, all comments that match with Condition are hidden.
+	KeepLatest int `json:"keep_latest,omitempty" yaml:"keep_latest" jsonschema:"description=The number of the latest comments kept in each group"`
+	// Classifier is the reason why comments are hidden.
+	// Classifier is either a classifier name such as OUTDATED or an expression returning a classifier name per comment.
+	Classifier string `json:"classifier,omitempty" jsonschema:"description=The reason why comments are hidden. A classifier name (ABUSE / DUPLICATE / OFF_TOPIC / OUTDATED / RESOLVED / SPAM) or an expression returning a classifier name. The default is OUTDATED"`
+}
+
+type hideConfigForJS HideConfig
+
+func (HideConfig) JSONSchema() *jsonschema.Schema {
+	a := jsonschema.Reflect(&hideConfigForJS{}).Definitions["hideConfigForJS"]
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: "string",
+			},
+			a,
+		},
+	}
+}
+
+func (hc *HideConfig) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		hc.Condition = s
+		return nil
+	}
+	var a hideConfigForJS
+	if err := unmarshal(&a); err != nil {
+		return fmt.Errorf("invalid config. hide config should be string or map[string]interface{}: %w", err)
+	}
+	*hc = HideConfig(a)
+	return nil
+}
+
+type ReviewCommentConfig struct {
+	Path string `json:"path" jsonschema:"description=File path. This is rendered as a template"`
+	Line string `json:"line" jsonschema:"description=Line number. This is rendered as a template"`
+	Side string `json:"side,omitempty" jsonschema:"description=The side of the diff,enum=LEFT,enum=RIGHT"`
+}
+
+func (rc ReviewCommentConfig) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties.Set("line", &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: "string",
+			},
+			{
+				Type: "integer",
+			},
+		},
+	})
 }
 
 type postConfigForJS PostConfig
@@ -95,17 +218,133 @@ func (pc *PostConfig) UnmarshalYAML(unmarshal func(any) error) error { //nolint:
 			}
 			pc.UpdateCondition = t
 		}
+		if rc, ok := m["review_comment"]; ok {
+			r, err := parseReviewCommentConfig(rc)
+			if err != nil {
+				return err
+			}
+			pc.ReviewComment = r
+		}
+		if tpl, ok := m["overflow"]; ok {
+			t, ok := tpl.(string)
+			if !ok {
+				return fmt.Errorf("invalid config. overflow should be string: %+v", tpl)
+			}
+			pc.Overflow = t
+		}
+		if tpl, ok := m["hide_previous"]; ok {
+			t, ok := tpl.(string)
+			if !ok {
+				return fmt.Errorf("invalid config. hide_previous should be string: %+v", tpl)
+			}
+			pc.HidePrevious = t
+		}
 		return nil
 	}
 	return fmt.Errorf("invalid config. post config should be string or map[string]intterface{}: %+v", val)
 }
 
+func parseReviewCommentConfig(val any) (*ReviewCommentConfig, error) {
+	m, ok := val.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid config. review_comment should be map[string]interface{}: %+v", val)
+	}
+	rc := &ReviewCommentConfig{}
+	for k, v := range m {
+		var s string
+		switch a := v.(type) {
+		case string:
+			s = a
+		case int:
+			s = strconv.Itoa(a)
+		default:
+			return nil, fmt.Errorf("invalid config. review_comment.%v should be string: %+v", k, v)
+		}
+		switch k {
+		case "path":
+			rc.Path = s
+		case "line":
+			rc.Line = s
+		case "side":
+			rc.Side = s
+		}
+	}
+	return rc, nil
+}
+
 type ExecConfig struct {
 	When               string   `json:"when" jsonschema:"description=Condition that this setting is chosen"`
 	Template           string   `json:"template,omitempty" jsonschema:"description=Comment template"`
 	TemplateForTooLong string   `json:"template_for_too_long,omitempty" yaml:"template_for_too_long"`
 	DontComment        bool     `json:"dont_comment,omitempty" yaml:"dont_comment" jsonschema:"description=Don't post a comment"`
 	EmbeddedVarNames   []string `json:"embedded_var_names,omitempty" yaml:"embedded_var_names" jsonschema:"description=Embedded variable names"`
+	// RunningTemplate is the template of the comment posted while the command is running.
+	// This is used if the exec command is run with --progress.
+	RunningTemplate string `json:"running_template,omitempty" yaml:"running_template" jsonschema:"description=Comment template posted while the command is running. This is used with --progress"`
+	// Timeout is the timeout of the command. The format is Go's time.Duration.
+	// As ExecConfig can't be selected by `when` before the command is run, the first timeout of the template key is used.
+	Timeout string `json:"timeout,omitempty" jsonschema:"description=The timeout of the command. The format is Go's time.Duration. e.g. 30m"`
+	// UpdateCondition Update the comment that matches with the condition.
+	// If multiple comments match, the latest comment is updated.
+	// If no comment matches, a new comment is created.
+	UpdateCondition string `json:"update,omitempty" yaml:"update" jsonschema:"description=Update comments that matches with the condition"`
+	// ReviewComment posts the comment to the file line of the pull request as a review comment.
+	// If the line isn't in the diff, the comment is posted to the pull request conversation.
+	ReviewComment *ReviewCommentConfig `json:"review_comment,omitempty" yaml:"review_comment" jsonschema:"description=Post the comment to the file line of the pull request"`
+	// Review is used when the output is `review`.
+	Review *ReviewConfig `json:"review,omitempty" jsonschema:"description=Pull request review. This is used when the output is review"`
+	// Parser parses the command output and the result is passed to templates as `.Annotations`.
+	Parser *ParserConfig `json:"parser,omitempty" jsonschema:"description=Parse the command output as annotations"`
+	// Overflow is the way to post a too long comment.
+	// If Overflow is empty, template_for_too_long is posted.
+	// If Overflow is gist, the too long command output is uploaded to a secret gist.
+	Overflow string `json:"overflow,omitempty" jsonschema:"description=How to post a too long comment. By default template_for_too_long is posted,enum=split,enum=gist"`
+	// HidePrevious hides previous comments after the comment is posted.
+	// HidePrevious is either a key of hide or a condition.
+	HidePrevious string `json:"hide_previous,omitempty" jsonschema:"description=Hide previous comments after the comment is posted. A key of hide or a condition"`
+}
+
+type ParserConfig struct {
+	Format string `json:"format" jsonschema:"description=Format of the command output,enum=gnu,enum=regexp,enum=checkstyle,enum=sarif"`
+	// Pattern is a regular expression with named groups.
+	// This is required if the format is regexp.
+	Pattern string `json:"pattern,omitempty" jsonschema:"description=Regular expression with named groups. This is required if the format is regexp"`
+	// Source is the parsed output. The default is combined_output.
+	Source string `json:"source,omitempty" jsonschema:"description=Parsed output. The default is combined_output,enum=stdout,enum=stderr,enum=combined_output"`
+	// File is a parsed file path. If File is set, the file is parsed instead of the command output.
+	File string `json:"file,omitempty" jsonschema:"description=Parsed file path. If this is set the file is parsed instead of the command output"`
+}
+
+type ReviewConfig struct {
+	// Events is a list of the review event and the condition.
+	// The first matching event is used. If no event matches, COMMENT is used.
+	Events   []*ReviewEventConfig   `json:"events,omitempty" jsonschema:"description=Review events. The first matching event is used. COMMENT is used if no event matches"`
+	Comments []*ReviewInlineComment `json:"comments,omitempty" jsonschema:"description=Inline comments of the review"`
+}
+
+type ReviewEventConfig struct {
+	When  string `json:"when" jsonschema:"description=Condition that this event is chosen"`
+	Event string `json:"event" jsonschema:"description=Review event,enum=APPROVE,enum=REQUEST_CHANGES,enum=COMMENT"`
+}
+
+func (rc ReviewEventConfig) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties.Set("when", &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: "string",
+			},
+			{
+				Type: "boolean",
+			},
+		},
+	})
+}
+
+type ReviewInlineComment struct {
+	ReviewCommentConfig `yaml:",inline"`
+
+	Range    string `json:"range,omitempty" jsonschema:"description=Expression returning a list. A comment is created per element and the element is passed to templates as .Item"`
+	Template string `json:"template" jsonschema:"description=Comment template"`
 }
 
 func (ec ExecConfig) JSONSchemaExtend(schema *jsonschema.Schema) {
@@ -121,4 +360,6 @@ func (ec ExecConfig) JSONSchemaExtend(schema *jsonschema.Schema) {
 	})
 }
 
-const defaultHideCondition = "Comment.HasMeta && Comment.Meta.SHA1 != Commit.SHA1"
+// defaultCondition is the default condition of hide and delete.
+// Comments posted by github-comment for other commits are hidden or deleted.
+const defaultCondition = "Comment.HasMeta && Comment.Meta.SHA1 != Commit.SHA1"
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPostConfig_UnmarshalYAML(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		yaml  string
+		exp   *PostConfig
+		isErr bool
+	}{
+		{
+			title: "string",
+			yaml:  `hello`,
+			exp: &PostConfig{
+				Template: "hello",
+			},
+		},
+		{
+			title: "map",
+			yaml: `
+template: hello
+update: "true"
+overflow: split
+hide_previous: default
+`,
+			exp: &PostConfig{
+				Template:        "hello",
+				UpdateCondition: "true",
+				Overflow:        "split",
+				HidePrevious:    "default",
+			},
+		},
+		{
+			title: "hide_previous isn't a string",
+			yaml: `
+template: hello
+hide_previous: [default]
+`,
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			pc := &PostConfig{}
+			err := yaml.Unmarshal([]byte(d.yaml), pc)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, pc)
+		})
+	}
+}
@@ -16,8 +16,13 @@ type ExistFile func(string) bool
 
 func (r *Reader) FindAndRead(cfgPath, wd string) (*Config, error) {
 	cfg := &Config{
-		Hide: map[string]string{
-			"default": defaultHideCondition,
+		Hide: map[string]*HideConfig{
+			"default": {
+				Condition: defaultCondition,
+			},
+		},
+		Delete: map[string]string{
+			"default": defaultCondition,
 		},
 	}
 	if cfgPath == "" {
@@ -31,17 +36,25 @@ func (r *Reader) FindAndRead(cfgPath, wd string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	if cfg.Hide == nil {
-		cfg.Hide = map[string]string{
-			"default": defaultHideCondition,
+	cfg.Hide = setDefaultCondition(cfg.Hide, &HideConfig{
+		Condition: defaultCondition,
+	})
+	cfg.Delete = setDefaultCondition(cfg.Delete, defaultCondition)
+	return cfg, nil
+}
+
+// setDefaultCondition sets the default condition to the key "default" if it isn't set.
+func setDefaultCondition[T any](conditions map[string]T, condition T) map[string]T {
+	if conditions == nil {
+		return map[string]T{
+			"default": condition,
 		}
-		return cfg, nil
 	}
-	if _, ok := cfg.Hide["default"]; ok {
-		return cfg, nil
+	if _, ok := conditions["default"]; ok {
+		return conditions
 	}
-	cfg.Hide["default"] = defaultHideCondition
-	return cfg, nil
+	conditions["default"] = condition
+	return conditions
 }
 
 func (r *Reader) find(wd string) (string, bool) {
@@ -2,19 +2,42 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
 
 	"github.com/suzuki-shunsuke/github-comment-metadata/metadata"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/markdown"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
 // GitHub is API to post a comment to GitHub
 type GitHub interface {
 	CreateComment(ctx context.Context, cmt *github.Comment) error
 	ListComments(ctx context.Context, pr *github.PullRequest) ([]*github.IssueComment, error)
-	HideComment(ctx context.Context, nodeID string) error
+	HideComment(ctx context.Context, nodeID, classifier string) error
+	UnhideComment(ctx context.Context, nodeID string) error
 	GetAuthenticatedUser(ctx context.Context) (string, error)
 	PRNumberWithSHA(ctx context.Context, owner, repo, sha string) (int, error)
+	DeleteComment(ctx context.Context, pr *github.PullRequest, commentID int64) error
+}
+
+// ReviewCreator creates pull request reviews.
+// GitHub implements ReviewCreator optionally because some forges don't support pull request reviews.
+type ReviewCreator interface {
+	CreateReview(ctx context.Context, review *github.Review) error
+}
+
+// CheckRunCreator creates check runs.
+// GitHub implements CheckRunCreator optionally because some forges don't support check runs.
+type CheckRunCreator interface {
+	CreateCheckRun(ctx context.Context, checkRun *github.CheckRun) error
 }
 
 type CommentController struct {
@@ -25,12 +48,65 @@ type CommentController struct {
 }
 
 func (c *CommentController) Post(ctx context.Context, cmt *github.Comment) error {
+	if len(cmt.Parts) != 0 || len(cmt.GroupCommentIDs) != 0 {
+		return c.postParts(ctx, cmt)
+	}
 	if err := c.GitHub.CreateComment(ctx, cmt); err != nil {
 		return fmt.Errorf("send a comment: %w", err)
 	}
 	return nil
 }
 
+// postParts posts parts of a comment split from a too long body.
+// Existing comments of the group are updated in order, and leftover comments are deleted.
+func (c *CommentController) postParts(ctx context.Context, cmt *github.Comment) error {
+	parts := cmt.Parts
+	if len(parts) == 0 {
+		parts = []string{cmt.Body}
+	}
+	ids := cmt.GroupCommentIDs
+	for i, part := range parts {
+		p := *cmt
+		p.Body = part
+		p.Parts = nil
+		p.GroupCommentIDs = nil
+		p.CommentID = 0
+		if i < len(ids) {
+			p.CommentID = ids[i]
+		}
+		if err := c.GitHub.CreateComment(ctx, &p); err != nil {
+			return fmt.Errorf("send a comment (%d/%d): %w", i+1, len(parts), err)
+		}
+	}
+	for _, id := range ids[min(len(parts), len(ids)):] {
+		if err := c.GitHub.DeleteComment(ctx, &github.PullRequest{
+			Org:      cmt.Org,
+			Repo:     cmt.Repo,
+			PRNumber: cmt.PRNumber,
+		}, id); err != nil {
+			return fmt.Errorf("delete a leftover comment: %w", err)
+		}
+	}
+	return nil
+}
+
+// partPrefixLength is the maximum length of the prefix "(i/n)" of each part.
+const partPrefixLength = 32
+
+// splitComment splits a too long body into parts.
+// Each part has the prefix "(i/n)" and the same embedded metadata.
+// If the body isn't too long, nil is returned.
+func splitComment(body, embeddedComment string) []string {
+	if len(body)+len(embeddedComment) <= github.MaxCommentLength {
+		return nil
+	}
+	parts := markdown.Split(body, github.MaxCommentLength-len(embeddedComment)-partPrefixLength)
+	for i, part := range parts {
+		parts[i] = fmt.Sprintf("(%d/%d)\n\n", i+1, len(parts)) + part + embeddedComment
+	}
+	return parts
+}
+
 func extractMetaFromComment(body string, data *map[string]any) bool {
 	f, _ := metadata.Extract(body, data)
 	return f
@@ -43,10 +119,296 @@ func (c *CommentController) complementMetaData(data map[string]any) {
 	if c.Platform == nil {
 		return
 	}
-	_ = metadata.SetCIEnv(c.Platform.CI(), c.Getenv, data)
+	ci := c.Platform.CI()
+	// github-comment-metadata doesn't support GitLab CI and Bitbucket Pipelines
+	switch ci {
+	case "gitlab-ci":
+		data["JobName"] = c.Getenv("CI_JOB_NAME")
+		data["JobID"] = c.Getenv("CI_JOB_ID")
+		return
+	case "bitbucket-pipelines":
+		data["JobID"] = c.Getenv("BITBUCKET_BUILD_NUMBER")
+		data["JobName"] = c.Getenv("BITBUCKET_STEP_UUID")
+		return
+	}
+	_ = metadata.SetCIEnv(ci, c.Getenv, data)
 }
 
 func (c *CommentController) getEmbeddedComment(data map[string]any) (string, error) {
 	c.complementMetaData(data)
 	return metadata.Convert(data) //nolint:wrapcheck
 }
+
+// setReviewComment renders the location of the review comment and sets it to the comment.
+// If the rendered path is empty, the comment is posted to the pull request conversation.
+func setReviewComment(renderer Renderer, rc *config.ReviewCommentConfig, templates map[string]string, params any, cmt *github.Comment) error {
+	if rc == nil {
+		return nil
+	}
+	loc, err := renderReviewCommentLocation(renderer, rc, templates, params)
+	if err != nil {
+		return err
+	}
+	if loc == nil {
+		return nil
+	}
+	cmt.Path = loc.Path
+	cmt.Line = loc.Line
+	cmt.Side = loc.Side
+	return nil
+}
+
+// renderReviewCommentLocation renders the path and line of the review comment.
+// If the rendered path is empty, nil is returned.
+func renderReviewCommentLocation(renderer Renderer, rc *config.ReviewCommentConfig, templates map[string]string, params any) (*github.ReviewComment, error) {
+	p, err := renderer.Render(rc.Path, templates, params)
+	if err != nil {
+		return nil, fmt.Errorf("render a review comment path: %w", err)
+	}
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return nil, nil //nolint:nilnil
+	}
+	l, err := renderer.Render(rc.Line, templates, params)
+	if err != nil {
+		return nil, fmt.Errorf("render a review comment line: %w", err)
+	}
+	line, err := strconv.Atoi(strings.TrimSpace(l))
+	if err != nil {
+		return nil, fmt.Errorf("parse a review comment line as an integer: %w", err)
+	}
+	if line <= 0 {
+		return nil, errors.New("a review comment line must be greater than 0")
+	}
+	return &github.ReviewComment{
+		Path: p,
+		Line: line,
+		Side: rc.Side,
+	}, nil
+}
+
+type ParamListComments struct {
+	Condition string
+	Org       string
+	Repo      string
+	SHA1      string
+	PRNumber  int
+	Vars      map[string]any
+	// IncludeReviews is true if pull request reviews and review comments are also listed
+	IncludeReviews bool
+}
+
+// complementTarget complements the repository and the pull request number of hide and delete
+// with the configuration file, the platform's environment variables, and the commit SHA1.
+// complement is nil if the platform isn't detected.
+func complementTarget(ctx context.Context, logger *slog.Logger, gh GitHub, cfg *config.Config, opts *option.Options, complement func() error) error {
+	if cfg.Base != nil {
+		if opts.Org == "" {
+			opts.Org = cfg.Base.Org
+		}
+		if opts.Repo == "" {
+			opts.Repo = cfg.Base.Repo
+		}
+	}
+
+	if complement != nil {
+		if err := complement(); err != nil {
+			return fmt.Errorf("failed to complement opts with platform built in environment variables: %w", err)
+		}
+	}
+
+	if opts.PRNumber == 0 && opts.SHA1 != "" {
+		prNum, err := gh.PRNumberWithSHA(ctx, opts.Org, opts.Repo, opts.SHA1)
+		if err != nil {
+			slogerr.WithError(logger, err).Warn("list associated prs",
+				"org", opts.Org,
+				"repo", opts.Repo,
+				"sha", opts.SHA1,
+			)
+		}
+		if prNum > 0 {
+			opts.PRNumber = prNum
+		}
+	}
+	return nil
+}
+
+// mergeVars merges vars into the variables of the configuration file and returns them.
+func mergeVars(cfg *config.Config, vars map[string]any) map[string]any {
+	if cfg.Vars == nil {
+		cfg.Vars = make(map[string]any, len(vars))
+	}
+	maps.Copy(cfg.Vars, vars)
+	return cfg.Vars
+}
+
+// newCommentParam returns the parameter of expressions to judge the comment.
+func newCommentParam(comment *github.IssueComment, param *ParamListComments, paramExpr map[string]any) map[string]any {
+	metadata := map[string]any{}
+	hasMeta := extractMetaFromComment(comment.Body, &metadata)
+	paramMap := map[string]any{
+		"Comment": map[string]any{
+			"Body":            comment.Body,
+			"Kind":            comment.Kind,
+			"CreatedAt":       comment.CreatedAt,
+			"Author":          comment.Author.Login,
+			"URL":             comment.URL,
+			"DatabaseID":      comment.DatabaseID,
+			"IsMinimized":     comment.IsMinimized,
+			"MinimizedReason": comment.MinimizedReason,
+			"Meta":            metadata,
+			"HasMeta":         hasMeta,
+		},
+		"Commit": map[string]any{
+			"Org":      param.Org,
+			"Repo":     param.Repo,
+			"PRNumber": param.PRNumber,
+			"SHA1":     param.SHA1,
+		},
+		"Vars": param.Vars,
+	}
+	maps.Copy(paramMap, paramExpr)
+	return paramMap
+}
+
+// listMatchedComments lists the pull request (issue) comments which match with the condition.
+// Comments excluded by isExcluded are ignored.
+func (c *CommentController) listMatchedComments( //nolint:funlen
+	ctx context.Context,
+	logger *slog.Logger,
+	param *ParamListComments,
+	paramExpr map[string]any,
+	isExcluded func(cmt *github.IssueComment, login string) bool,
+) ([]*github.IssueComment, error) {
+	if param.Condition == "" {
+		logger.Debug("the condition to select comments isn't set")
+		return nil, nil
+	}
+	login, err := c.GitHub.GetAuthenticatedUser(ctx)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("get an authenticated user")
+	}
+
+	comments, err := c.GitHub.ListComments(ctx, &github.PullRequest{
+		Org:            param.Org,
+		Repo:           param.Repo,
+		PRNumber:       param.PRNumber,
+		IncludeReviews: param.IncludeReviews,
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	logger.Debug("get comments",
+		"count", len(comments),
+		"org", param.Org,
+		"repo", param.Repo,
+		"pr_number", param.PRNumber,
+	)
+
+	matchedComments := []*github.IssueComment{}
+	prg, err := c.Expr.Compile(param.Condition)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	for _, comment := range comments {
+		nodeID := comment.ID
+		// TODO remove these filters
+		if isExcluded(comment, login) {
+			logger.Debug("exclude a comment",
+				"node_id", nodeID,
+				"login", login,
+			)
+			continue
+		}
+
+		paramMap := newCommentParam(comment, param, paramExpr)
+
+		logger.Debug("judge whether an existing comment matches with the condition",
+			"node_id", nodeID,
+			"condition", param.Condition,
+			"param", paramMap,
+		)
+		f, err := prg.Run(paramMap)
+		if err != nil {
+			slogerr.WithError(logger, err).Error("judge whether an existing comment matches with the condition",
+				"node_id", nodeID,
+			)
+			continue
+		}
+		if !f {
+			continue
+		}
+		matchedComments = append(matchedComments, comment)
+	}
+	return matchedComments, nil
+}
+
+// setUpdatedCommentID sets the id of the latest comment which matches with the condition to the comment.
+// Then the comment is updated instead of creating a new comment.
+// If group is true, comments which have the same GroupID as the latest comment are treated as a group of split comments.
+// If no comment matches, the comment id isn't changed.
+func (c *CommentController) setUpdatedCommentID(ctx context.Context, logger *slog.Logger, cmt *github.Comment, updateCondition string, group bool) error {
+	comments, err := c.listMatchedComments(ctx, logger, &ParamListComments{
+		Condition: updateCondition,
+		Org:       cmt.Org,
+		Repo:      cmt.Repo,
+		SHA1:      cmt.SHA1,
+		PRNumber:  cmt.PRNumber,
+		Vars:      cmt.Vars,
+	}, nil, isExcludedUpdatedComment)
+	if err != nil {
+		return fmt.Errorf("list issue or pull request comments to update: %w", err)
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+	// comments are sorted in the ascending order of the creation time
+	comment := comments[len(comments)-1]
+	logger.Debug("update an existing comment",
+		"node_id", comment.ID,
+		"comment_id", comment.DatabaseID,
+	)
+	cmt.CommentID = comment.DatabaseID
+	if group {
+		cmt.GroupCommentIDs = getGroupCommentIDs(comments, comment)
+		logger.Debug("update a group of split comments",
+			"comment_ids", cmt.GroupCommentIDs,
+		)
+	}
+	return nil
+}
+
+// getGroupCommentIDs returns ids of comments which have the same GroupID as the latest comment.
+// GroupID is embedded into comments split from a too long body, and it is unique to each run.
+// If the latest comment doesn't have GroupID, only the latest comment is returned.
+func getGroupCommentIDs(comments []*github.IssueComment, latest *github.IssueComment) []int64 {
+	groupID := getGroupID(latest)
+	if groupID == "" {
+		return []int64{latest.DatabaseID}
+	}
+	ids := []int64{}
+	for _, comment := range comments {
+		if getGroupID(comment) == groupID {
+			ids = append(ids, comment.DatabaseID)
+		}
+	}
+	return ids
+}
+
+func getGroupID(comment *github.IssueComment) string {
+	meta := map[string]any{}
+	if !extractMetaFromComment(comment.Body, &meta) {
+		return ""
+	}
+	groupID, _ := meta["GroupID"].(string)
+	return groupID
+}
+
+func isExcludedUpdatedComment(cmt *github.IssueComment, login string) bool {
+	if cmt.IsMinimized {
+		// ignore minimized comments
+		return true
+	}
+	// ignore other users' comments
+	return login != "" && cmt.Author.Login != login
+}
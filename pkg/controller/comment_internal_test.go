@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// partsGitHub records posted and deleted comments.
+type partsGitHub struct {
+	*github.Mock
+
+	created []*github.Comment
+	deleted []int64
+}
+
+func (g *partsGitHub) CreateComment(_ context.Context, cmt *github.Comment) error {
+	g.created = append(g.created, cmt)
+	return nil
+}
+
+func (g *partsGitHub) DeleteComment(_ context.Context, _ *github.PullRequest, commentID int64) error {
+	g.deleted = append(g.deleted, commentID)
+	return nil
+}
+
+func TestCommentController_postParts(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title      string
+		parts      []string
+		ids        []int64
+		expCreated []*github.Comment
+		expDeleted []int64
+	}{
+		{
+			title: "create parts",
+			parts: []string{"a", "b"},
+			expCreated: []*github.Comment{
+				{PRNumber: 1, Body: "a"},
+				{PRNumber: 1, Body: "b"},
+			},
+		},
+		{
+			title: "update parts and create extra parts",
+			parts: []string{"a", "b", "c"},
+			ids:   []int64{10, 11},
+			expCreated: []*github.Comment{
+				{PRNumber: 1, Body: "a", CommentID: 10},
+				{PRNumber: 1, Body: "b", CommentID: 11},
+				{PRNumber: 1, Body: "c"},
+			},
+		},
+		{
+			title: "delete leftover comments",
+			parts: []string{"a"},
+			ids:   []int64{10, 11, 12},
+			expCreated: []*github.Comment{
+				{PRNumber: 1, Body: "a", CommentID: 10},
+			},
+			expDeleted: []int64{11, 12},
+		},
+		{
+			title: "a group of split comments is updated with a short body",
+			ids:   []int64{10, 11},
+			expCreated: []*github.Comment{
+				{PRNumber: 1, Body: "body", CommentID: 10},
+			},
+			expDeleted: []int64{11},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &partsGitHub{Mock: &github.Mock{}}
+			ctrl := &CommentController{GitHub: gh}
+			require.NoError(t, ctrl.Post(context.Background(), &github.Comment{
+				PRNumber:        1,
+				Body:            "body",
+				Parts:           d.parts,
+				GroupCommentIDs: d.ids,
+			}))
+			require.Equal(t, d.expCreated, gh.created)
+			require.Equal(t, d.expDeleted, gh.deleted)
+		})
+	}
+}
+
+func Test_getGroupCommentIDs(t *testing.T) {
+	t.Parallel()
+	newComment := func(databaseID int64, groupID string) *github.IssueComment {
+		return &github.IssueComment{
+			DatabaseID: databaseID,
+			Body:       `<!-- github-comment: {"SHA1":"abc","TemplateKey":"plan","GroupID":"` + groupID + `"} -->`,
+		}
+	}
+	comments := []*github.IssueComment{
+		// comments posted by an earlier run on the same commit have the same metadata except GroupID
+		newComment(1, "foo"),
+		newComment(2, "foo"),
+		newComment(3, "bar"),
+		newComment(4, "bar"),
+	}
+	require.Equal(t, []int64{3, 4}, getGroupCommentIDs(comments, comments[3]))
+	require.Equal(t, []int64{1, 2}, getGroupCommentIDs(comments, comments[1]))
+
+	noGroup := []*github.IssueComment{
+		{DatabaseID: 1, Body: `<!-- github-comment: {"SHA1":"abc","TemplateKey":"plan"} -->`},
+		{DatabaseID: 2, Body: `<!-- github-comment: {"SHA1":"abc","TemplateKey":"plan"} -->`},
+		{DatabaseID: 3, Body: "hello"},
+	}
+	require.Equal(t, []int64{2}, getGroupCommentIDs(noGroup, noGroup[1]))
+	require.Equal(t, []int64{3}, getGroupCommentIDs(noGroup, noGroup[2]))
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+)
+
+type DeleteController struct {
+	// Wd is a path to the working directory
+	Wd string
+	// Getenv returns the environment variable. os.Getenv
+	Getenv   func(string) string
+	Stderr   io.Writer
+	GitHub   GitHub
+	Platform Platform
+	Config   *config.Config
+	Expr     Expr
+}
+
+func (c *DeleteController) Delete(ctx context.Context, logger *slog.Logger, opts *option.DeleteOptions) error {
+	param, err := c.getParamListDeletedComments(ctx, logger, opts)
+	if err != nil {
+		return err
+	}
+	comments, err := c.listDeletedComments(ctx, logger, param)
+	if err != nil {
+		return err
+	}
+	logger.Debug("comments which would be deleted",
+		"count", len(comments),
+	)
+	return c.deleteComments(ctx, logger, &github.PullRequest{
+		Org:      param.Org,
+		Repo:     param.Repo,
+		PRNumber: param.PRNumber,
+	}, comments)
+}
+
+type ParamListDeletedComments struct {
+	Condition string
+	DeleteKey string
+	Org       string
+	Repo      string
+	SHA1      string
+	PRNumber  int
+	Vars      map[string]any
+}
+
+func (c *DeleteController) getParamListDeletedComments(ctx context.Context, logger *slog.Logger, opts *option.DeleteOptions) (*ParamListDeletedComments, error) {
+	var complement func() error
+	if c.Platform != nil {
+		complement = func() error {
+			return c.Platform.ComplementDelete(opts) //nolint:wrapcheck
+		}
+	}
+	if err := complementTarget(ctx, logger, c.GitHub, c.Config, &opts.Options, complement); err != nil {
+		return nil, err
+	}
+	if err := option.ValidateDelete(opts); err != nil {
+		return nil, fmt.Errorf("opts is invalid: %w", err)
+	}
+
+	deleteCondition := opts.Condition
+	if deleteCondition == "" {
+		a, ok := c.Config.Delete[opts.DeleteKey]
+		if !ok {
+			return nil, errors.New("invalid delete-key: " + opts.DeleteKey)
+		}
+		deleteCondition = a
+	}
+
+	return &ParamListDeletedComments{
+		PRNumber:  opts.PRNumber,
+		Org:       opts.Org,
+		Repo:      opts.Repo,
+		SHA1:      opts.SHA1,
+		Condition: deleteCondition,
+		DeleteKey: opts.DeleteKey,
+		Vars:      mergeVars(c.Config, opts.Vars),
+	}, nil
+}
+
+func (c *DeleteController) listDeletedComments(ctx context.Context, logger *slog.Logger, param *ParamListDeletedComments) ([]*github.IssueComment, error) {
+	cmtCtrl := CommentController{
+		GitHub: c.GitHub,
+		Expr:   c.Expr,
+		Getenv: c.Getenv,
+	}
+	return cmtCtrl.listMatchedComments(ctx, logger, &ParamListComments{
+		Condition: param.Condition,
+		Org:       param.Org,
+		Repo:      param.Repo,
+		SHA1:      param.SHA1,
+		PRNumber:  param.PRNumber,
+		Vars:      param.Vars,
+	}, map[string]any{
+		"DeleteKey": param.DeleteKey,
+	}, isExcludedDeletedComment)
+}
+
+// deleteComments deletes comments one by one.
+// Errors are aggregated and returned after all comments are processed.
+func (c *DeleteController) deleteComments(ctx context.Context, logger *slog.Logger, pr *github.PullRequest, comments []*github.IssueComment) error {
+	nodeIDs := make([]string, len(comments))
+	databaseIDs := make(map[string]int64, len(comments))
+	for i, comment := range comments {
+		nodeIDs[i] = comment.ID
+		databaseIDs[comment.ID] = comment.DatabaseID
+	}
+	return processComments(ctx, logger, nodeIDs, 1, &commentAction{
+		verb:           "delete",
+		pastParticiple: "deleted",
+		run: func(ctx context.Context, nodeID string) error {
+			return c.GitHub.DeleteComment(ctx, pr, databaseIDs[nodeID])
+		},
+	})
+}
+
+func isExcludedDeletedComment(cmt *github.IssueComment, login string) bool {
+	if !cmt.ViewerCanDelete {
+		return true
+	}
+	// GitHub Actions's GITHUB_TOKEN secret doesn't have a permission to get an authenticated user.
+	// So if `login` is empty, we give up filtering comments by login.
+	if login != "" && cmt.Author.Login != login {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+)
+
+// deleteGitHub records deleted comments.
+type deleteGitHub struct {
+	*github.Mock
+
+	comments []*github.IssueComment
+	deleted  []int64
+	failed   map[int64]struct{}
+}
+
+func (g *deleteGitHub) ListComments(_ context.Context, _ *github.PullRequest) ([]*github.IssueComment, error) {
+	return g.comments, nil
+}
+
+func (g *deleteGitHub) DeleteComment(_ context.Context, _ *github.PullRequest, commentID int64) error {
+	if _, ok := g.failed[commentID]; ok {
+		return errors.New("failed to delete a comment")
+	}
+	g.deleted = append(g.deleted, commentID)
+	return nil
+}
+
+func TestDeleteController_Delete(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	newComment := func(databaseID int64, sha1, login string) *github.IssueComment {
+		cmt := &github.IssueComment{
+			ID:              strconv.FormatInt(databaseID, 10),
+			DatabaseID:      databaseID,
+			Body:            `<!-- github-comment: {"SHA1":"` + sha1 + `"} -->`,
+			ViewerCanDelete: true,
+		}
+		cmt.Author.Login = login
+		return cmt
+	}
+	noMeta := newComment(4, "", "octocat")
+	noMeta.Body = "hello"
+	cannotDelete := newComment(5, "old", "octocat")
+	cannotDelete.ViewerCanDelete = false
+	comments := []*github.IssueComment{
+		newComment(1, "old", "octocat"),
+		newComment(2, "new", "octocat"),
+		// comments posted by other users are ignored
+		newComment(3, "old", "other"),
+		noMeta,
+		cannotDelete,
+	}
+	data := []struct {
+		title      string
+		deleteKey  string
+		condition  string
+		failed     map[int64]struct{}
+		isErr      bool
+		expDeleted []int64
+	}{
+		{
+			title:      "default condition",
+			deleteKey:  "default",
+			expDeleted: []int64{1},
+		},
+		{
+			title:      "condition",
+			condition:  `Comment.Body == "hello" || Comment.HasMeta`,
+			expDeleted: []int64{1, 2, 4},
+		},
+		{
+			title:      "delete key",
+			deleteKey:  "all",
+			expDeleted: []int64{1, 2, 4},
+		},
+		{
+			title:     "invalid delete key",
+			deleteKey: "foo",
+			isErr:     true,
+		},
+		{
+			title:      "failures are returned after all comments are processed",
+			deleteKey:  "all",
+			failed:     map[int64]struct{}{1: {}},
+			isErr:      true,
+			expDeleted: []int64{2, 4},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &deleteGitHub{
+				Mock:     &github.Mock{Login: "octocat"},
+				comments: comments,
+				failed:   d.failed,
+			}
+			ctrl := &DeleteController{
+				GitHub: gh,
+				Expr:   &expr.Expr{},
+				Config: &config.Config{
+					Delete: map[string]string{
+						"default": "Comment.HasMeta && Comment.Meta.SHA1 != Commit.SHA1",
+						"all":     "true",
+					},
+				},
+			}
+			err := ctrl.Delete(context.Background(), logger, &option.DeleteOptions{
+				Options: option.Options{
+					Org:      "suzuki-shunsuke",
+					Repo:     "github-comment",
+					PRNumber: 1,
+					SHA1:     "new",
+					Token:    "xxx",
+				},
+				DeleteKey: d.deleteKey,
+				Condition: d.condition,
+			})
+			if d.isErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, d.expDeleted, gh.deleted)
+		})
+	}
+}
@@ -2,12 +2,17 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
@@ -15,6 +20,7 @@ import (
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/parser"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/template"
 	"github.com/suzuki-shunsuke/go-error-with-exit-code/ecerror"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
@@ -34,6 +40,14 @@ type ExecController struct {
 	Platform Platform
 	Config   *config.Config
 	Fs       afero.Fs
+	// Uploader uploads a too long command output instead of a secret gist. If Uploader is nil, GitHub is used
+	Uploader Uploader
+}
+
+// Uploader uploads a too long command output and returns the URL.
+// GitHub implements Uploader optionally because some forges don't support gists.
+type Uploader interface {
+	CreateGist(ctx context.Context, gist *github.Gist) (string, error)
 }
 
 func (c *ExecController) Exec(ctx context.Context, logger *slog.Logger, opts *option.ExecOptions) error { //nolint:funlen,cyclop
@@ -72,11 +86,29 @@ func (c *ExecController) Exec(ctx context.Context, logger *slog.Logger, opts *op
 		return errors.New("command is required")
 	}
 
-	result, execErr := c.Executor.Run(ctx, &execute.Params{
-		Cmd:   opts.Args[0],
-		Args:  opts.Args[1:],
-		Stdin: c.Stdin,
-	})
+	timeout, err := c.getTimeout(opts)
+	if err != nil {
+		return fmt.Errorf("get the timeout of the command: %w", err)
+	}
+
+	var prog *progress
+	if opts.Progress && !opts.SkipComment {
+		prog = c.startProgress(ctx, logger, opts)
+	}
+	params := &execute.Params{
+		Cmd:     opts.Args[0],
+		Args:    opts.Args[1:],
+		Stdin:   c.Stdin,
+		Timeout: timeout,
+	}
+	if prog != nil {
+		params.Output = prog.output
+	}
+	result, execErr := c.Executor.Run(ctx, params)
+	var progressCommentID int64
+	if prog != nil {
+		progressCommentID = prog.stop()
+	}
 
 	if opts.SkipComment {
 		if execErr != nil {
@@ -113,20 +145,28 @@ func (c *ExecController) Exec(ctx context.Context, logger *slog.Logger, opts *op
 		CombinedOutput: result.CombinedOutput,
 	})
 	if err := c.post(ctx, logger, execConfigs, &ExecCommentParams{
-		ExitCode:       result.ExitCode,
-		Command:        result.Cmd,
-		JoinCommand:    joinCommand,
-		Stdout:         result.Stdout,
-		Stderr:         result.Stderr,
-		CombinedOutput: result.CombinedOutput,
-		PRNumber:       opts.PRNumber,
-		Org:            opts.Org,
-		Repo:           opts.Repo,
-		SHA1:           opts.SHA1,
-		TemplateKey:    opts.TemplateKey,
-		Template:       opts.Template,
-		Vars:           cfg.Vars,
-		Outputs:        opts.Outputs,
+		ExitCode:          result.ExitCode,
+		Command:           result.Cmd,
+		JoinCommand:       joinCommand,
+		Stdout:            result.Stdout,
+		Stderr:            result.Stderr,
+		CombinedOutput:    result.CombinedOutput,
+		TimedOut:          result.TimedOut,
+		Signal:            result.Signal,
+		Duration:          result.Duration,
+		StartedAt:         result.StartedAt,
+		FinishedAt:        result.FinishedAt,
+		Rusage:            result.Rusage,
+		PRNumber:          opts.PRNumber,
+		Org:               opts.Org,
+		Repo:              opts.Repo,
+		SHA1:              opts.SHA1,
+		TemplateKey:       opts.TemplateKey,
+		Template:          opts.Template,
+		Vars:              cfg.Vars,
+		Outputs:           opts.Outputs,
+		UpdateCondition:   opts.UpdateCondition,
+		progressCommentID: progressCommentID,
 	}, templates); err != nil {
 		if !opts.Silent {
 			fmt.Fprintf(c.Stderr, "github-comment error: %+v\n", err)
@@ -145,6 +185,18 @@ type ExecCommentParams struct {
 	Command        string
 	JoinCommand    string
 	ExitCode       int
+	// TimedOut is true if the command was stopped because of the timeout
+	TimedOut bool
+	// Signal is the name of the signal which terminated the command. e.g. interrupt
+	Signal string
+	// Duration is the elapsed time of the command
+	Duration time.Duration
+	// StartedAt is the time when the command started
+	StartedAt time.Time
+	// FinishedAt is the time when the command finished
+	FinishedAt time.Time
+	// Rusage is the resource usage of the command such as the maximum resident set size and CPU time
+	Rusage execute.Rusage
 	// PRNumber is the pull request number where the comment is posted
 	PRNumber int
 	// Org is the GitHub Organization or User name
@@ -157,6 +209,17 @@ type ExecCommentParams struct {
 	Template    string
 	Vars        map[string]any
 	Outputs     []*option.Output
+	// UpdateCondition is passed by the command line option.
+	// This takes precedence over the configuration `update`.
+	UpdateCondition string
+	// Annotations are findings parsed from the command output by the parser.
+	Annotations []*parser.Annotation
+	// OverflowURL is the URL of the secret gist where the too long command output is uploaded.
+	// OverflowURL is empty unless the configuration `overflow` is gist and the command output is too long.
+	OverflowURL string
+	// progressCommentID is the id of the progress comment posted by --progress.
+	// The progress comment is updated with the result instead of posting a new comment.
+	progressCommentID int64
 }
 
 type Executor interface {
@@ -166,6 +229,7 @@ type Executor interface {
 type Expr interface {
 	Match(expression string, params any) (bool, error)
 	Compile(expression string) (expr.Program, error)
+	CompileAny(expression string) (expr.AnyProgram, error)
 }
 
 func (c *ExecController) getExecConfigs(cfg *config.Config, opts *option.ExecOptions) ([]*config.ExecConfig, error) {
@@ -193,6 +257,32 @@ func (c *ExecController) getExecConfigs(cfg *config.Config, opts *option.ExecOpt
 	return execConfigs, nil
 }
 
+// getTimeout returns the timeout of the command.
+// The command line option takes precedence over the configuration.
+// As ExecConfig can't be selected by `when` before the command is run, the first timeout of the template key is used.
+// If the timeout is invalid, an error is returned so that the command isn't run without the timeout.
+func (c *ExecController) getTimeout(opts *option.ExecOptions) (time.Duration, error) {
+	if opts.Timeout > 0 {
+		return opts.Timeout, nil
+	}
+	execConfigs, err := c.getExecConfigs(c.Config, opts)
+	if err != nil {
+		// the error is returned after the command is run
+		return 0, nil
+	}
+	for _, execConfig := range execConfigs {
+		if execConfig.Timeout == "" {
+			continue
+		}
+		timeout, err := time.ParseDuration(execConfig.Timeout)
+		if err != nil {
+			return 0, fmt.Errorf("parse timeout %q as a duration: %w", execConfig.Timeout, err)
+		}
+		return timeout, nil
+	}
+	return 0, nil
+}
+
 // getExecConfig returns matched ExecConfig.
 // If no ExecConfig matches, the second returned value is false.
 func (c *ExecController) getExecConfig(
@@ -211,35 +301,33 @@ func (c *ExecController) getExecConfig(
 	return nil, false, nil
 }
 
-// getComment returns Comment.
-// If the second returned value is false, no comment is posted.
-func (c *ExecController) getComment(execConfigs []*config.ExecConfig, cmtParams *ExecCommentParams, templates map[string]string) (*github.Comment, bool, error) { //nolint:funlen
-	tpl := cmtParams.Template
-	tplForTooLong := ""
-	var embeddedVarNames []string
-	if tpl == "" {
-		execConfig, f, err := c.getExecConfig(execConfigs, cmtParams)
-		if err != nil {
-			return nil, false, err
-		}
-		if !f {
-			return nil, false, nil
-		}
-		if execConfig.DontComment {
-			return nil, false, nil
-		}
-		tpl = execConfig.Template
-		tplForTooLong = execConfig.TemplateForTooLong
-		embeddedVarNames = execConfig.EmbeddedVarNames
+// selectExecConfig returns ExecConfig used to post a comment.
+// If the template is passed by the command line option, ExecConfig is created from it.
+// If no ExecConfig matches, the second returned value is false.
+func (c *ExecController) selectExecConfig(
+	execConfigs []*config.ExecConfig, cmtParams *ExecCommentParams,
+) (*config.ExecConfig, bool, error) {
+	if cmtParams.Template != "" {
+		return &config.ExecConfig{
+			Template: cmtParams.Template,
+		}, true, nil
 	}
+	return c.getExecConfig(execConfigs, cmtParams)
+}
 
-	body, err := c.Renderer.Render(tpl, templates, cmtParams)
+// getComment returns Comment.
+func (c *ExecController) getComment(execConfig *config.ExecConfig, cmtParams *ExecCommentParams, templates map[string]string) (*github.Comment, error) {
+	render := c.Renderer.Render
+	if execConfig.Overflow == config.OverflowSplit {
+		render = c.Renderer.RenderWithoutTruncation
+	}
+	body, err := render(execConfig.Template, templates, cmtParams)
 	if err != nil {
-		return nil, false, fmt.Errorf("render a comment template: %w", err)
+		return nil, fmt.Errorf("render a comment template: %w", err)
 	}
-	bodyForTooLong, err := c.Renderer.Render(tplForTooLong, templates, cmtParams)
+	bodyForTooLong, err := c.Renderer.Render(execConfig.TemplateForTooLong, templates, cmtParams)
 	if err != nil {
-		return nil, false, fmt.Errorf("render a comment template_for_too_long: %w", err)
+		return nil, fmt.Errorf("render a comment template_for_too_long: %w", err)
 	}
 
 	cmtCtrl := CommentController{
@@ -249,26 +337,38 @@ func (c *ExecController) getComment(execConfigs []*config.ExecConfig, cmtParams
 		Platform: c.Platform,
 	}
 
-	embeddedMetadata := make(map[string]any, len(embeddedVarNames))
-	for _, name := range embeddedVarNames {
+	embeddedMetadata := make(map[string]any, len(execConfig.EmbeddedVarNames))
+	for _, name := range execConfig.EmbeddedVarNames {
 		if v, ok := cmtParams.Vars[name]; ok {
 			embeddedMetadata[name] = v
 		}
 	}
 
-	embeddedComment, err := cmtCtrl.getEmbeddedComment(map[string]any{
+	data := map[string]any{
 		"SHA1":        cmtParams.SHA1,
 		"TemplateKey": cmtParams.TemplateKey,
 		"Vars":        embeddedMetadata,
-	})
+	}
+	if execMetadata := getExecMetadata(cmtParams); execMetadata != nil {
+		data["Exec"] = execMetadata
+	}
+	if execConfig.Overflow == config.OverflowSplit {
+		// GroupID distinguishes split comments of this run from comments of other runs
+		data["GroupID"] = rand.Text()
+	}
+	embeddedComment, err := cmtCtrl.getEmbeddedComment(data)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
+	var parts []string
+	if execConfig.Overflow == config.OverflowSplit {
+		parts = splitComment(body, embeddedComment)
+	}
 	body += embeddedComment
 	bodyForTooLong += embeddedComment
 
-	return &github.Comment{
+	cmt := &github.Comment{
 		PRNumber:       cmtParams.PRNumber,
 		Org:            cmtParams.Org,
 		Repo:           cmtParams.Repo,
@@ -277,43 +377,363 @@ func (c *ExecController) getComment(execConfigs []*config.ExecConfig, cmtParams
 		SHA1:           cmtParams.SHA1,
 		Vars:           cmtParams.Vars,
 		TemplateKey:    cmtParams.TemplateKey,
-	}, true, nil
+		Parts:          parts,
+	}
+	if cmtParams.PRNumber != 0 {
+		if err := setReviewComment(c.Renderer, execConfig.ReviewComment, templates, cmtParams, cmt); err != nil {
+			return nil, err
+		}
+	}
+	return cmt, nil
+}
+
+// getExecMetadata returns the result of the command embedded in the comment.
+// Durations are in seconds and MaxRSS is in bytes so that they can be aggregated easily.
+func getExecMetadata(cmtParams *ExecCommentParams) map[string]any {
+	if cmtParams.StartedAt.IsZero() {
+		return nil
+	}
+	return map[string]any{
+		"ExitCode":   cmtParams.ExitCode,
+		"StartedAt":  cmtParams.StartedAt,
+		"FinishedAt": cmtParams.FinishedAt,
+		"Duration":   cmtParams.Duration.Seconds(),
+		"MaxRSS":     cmtParams.Rusage.MaxRSS,
+		"UserTime":   cmtParams.Rusage.UserTime.Seconds(),
+		"SystemTime": cmtParams.Rusage.SystemTime.Seconds(),
+	}
 }
 
 func (c *ExecController) post(
 	ctx context.Context, logger *slog.Logger, execConfigs []*config.ExecConfig, cmtParams *ExecCommentParams,
 	templates map[string]string,
 ) error {
-	cmt, f, err := c.getComment(execConfigs, cmtParams, templates)
+	execConfig, f, err := c.selectExecConfig(execConfigs, cmtParams)
 	if err != nil {
 		return err
 	}
-	if !f {
-		return nil
+	if f && execConfig.Parser != nil {
+		// the comment and the check run are posted even if the output can't be parsed,
+		// because they matter most when the command crashes
+		annotations, err := c.parse(execConfig.Parser, cmtParams)
+		if err != nil {
+			slogerr.WithError(logger, err).Warn("parse the command output")
+		}
+		cmtParams.Annotations = annotations
+	}
+	if f && execConfig.Overflow == config.OverflowGist {
+		c.uploadOverflow(ctx, logger, cmtParams)
+	}
+	if (!f || execConfig.DontComment) && cmtParams.progressCommentID != 0 {
+		c.deleteProgressComment(ctx, logger, cmtParams)
+	}
+	var cmt *github.Comment
+	if f && !execConfig.DontComment {
+		a, err := c.getComment(execConfig, cmtParams, templates)
+		if err != nil {
+			return err
+		}
+		cmt = a
+		logger.Debug("comment meta data",
+			"org", cmt.Org,
+			"repo", cmt.Repo,
+			"pr_number", cmt.PRNumber,
+			"sha", cmt.SHA1,
+		)
 	}
-	logger.Debug("comment meta data",
-		"org", cmt.Org,
-		"repo", cmt.Repo,
-		"pr_number", cmt.PRNumber,
-		"sha", cmt.SHA1,
-	)
 
 	for _, out := range cmtParams.Outputs {
-		if err := c.handleOutput(ctx, cmt, out); err != nil {
+		// Check Runs are created even if no comment is posted
+		if out.CheckRun != "" {
+			if err := c.createCheckRun(ctx, cmtParams, templates, out.CheckRun); err != nil {
+				return err
+			}
+			continue
+		}
+		if cmt == nil {
+			continue
+		}
+		if err := c.handleOutput(ctx, logger, execConfig, cmt, cmtParams, templates, out); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *ExecController) handleOutput(ctx context.Context, cmt *github.Comment, out *option.Output) error {
+// deleteProgressComment deletes the progress comment if no comment is posted.
+func (c *ExecController) deleteProgressComment(ctx context.Context, logger *slog.Logger, cmtParams *ExecCommentParams) {
+	if err := c.GitHub.DeleteComment(ctx, &github.PullRequest{
+		Org:      cmtParams.Org,
+		Repo:     cmtParams.Repo,
+		PRNumber: cmtParams.PRNumber,
+	}, cmtParams.progressCommentID); err != nil {
+		slogerr.WithError(logger, err).Warn("delete the progress comment",
+			"comment_id", cmtParams.progressCommentID,
+		)
+	}
+}
+
+// uploadOverflow uploads the too long command output to a secret gist and sets the URL to OverflowURL.
+// If Uploader is set, the output is uploaded by Uploader instead of a secret gist.
+// If it fails to upload the output, the comment is posted without the URL.
+func (c *ExecController) uploadOverflow(ctx context.Context, logger *slog.Logger, cmtParams *ExecCommentParams) {
+	if len(cmtParams.CombinedOutput) <= template.MaxWrapCodeLength {
+		return
+	}
+	uploader := c.Uploader
+	if uploader == nil {
+		a, ok := c.GitHub.(Uploader)
+		if !ok {
+			logger.Warn("the forge doesn't support gists, so the command output isn't uploaded")
+			return
+		}
+		uploader = a
+	}
+	u, err := uploader.CreateGist(ctx, &github.Gist{
+		Description: fmt.Sprintf("github-comment: %s/%s $ %s", cmtParams.Org, cmtParams.Repo, cmtParams.JoinCommand),
+		FileName:    "output.log",
+		Content:     cmtParams.CombinedOutput,
+	})
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("upload the command output to a gist")
+		return
+	}
+	logger.Debug("upload the command output to a gist", "url", u)
+	cmtParams.OverflowURL = u
+}
+
+// parse parses the command output or the file and returns annotations.
+func (c *ExecController) parse(parserConfig *config.ParserConfig, cmtParams *ExecCommentParams) ([]*parser.Annotation, error) {
+	var input []byte
+	if parserConfig.File != "" {
+		b, err := afero.ReadFile(c.Fs, parserConfig.File)
+		if err != nil {
+			return nil, fmt.Errorf("read a file to parse: %w", err)
+		}
+		input = b
+	} else {
+		switch parserConfig.Source {
+		case "stdout":
+			input = []byte(cmtParams.Stdout)
+		case "stderr":
+			input = []byte(cmtParams.Stderr)
+		case "", "combined_output":
+			input = []byte(cmtParams.CombinedOutput)
+		default:
+			return nil, errors.New("invalid parser source. The source must be either stdout, stderr, or combined_output: " + parserConfig.Source)
+		}
+	}
+	annotations, err := parser.Parse(parserConfig.Format, parserConfig.Pattern, input)
+	if err != nil {
+		return nil, fmt.Errorf("parse the command output: %w", err)
+	}
+	return annotations, nil
+}
+
+// maxCheckRunTextLength is the maximum length of the check run's summary and text.
+const maxCheckRunTextLength = 65535
+
+func (c *ExecController) createCheckRun(ctx context.Context, cmtParams *ExecCommentParams, templates map[string]string, name string) error {
+	creator, ok := c.GitHub.(CheckRunCreator)
+	if !ok {
+		return errors.New("the forge doesn't support check runs")
+	}
+	if cmtParams.SHA1 == "" {
+		return errors.New("a check run requires a commit sha1")
+	}
+	summary, err := c.Renderer.Render(`{{template "check_run_summary" .}}`, templates, cmtParams)
+	if err != nil {
+		return fmt.Errorf("render a check run summary: %w", err)
+	}
+	conclusion := "success"
+	if cmtParams.ExitCode != 0 {
+		conclusion = "failure"
+	}
+	if err := creator.CreateCheckRun(ctx, &github.CheckRun{
+		Org:         cmtParams.Org,
+		Repo:        cmtParams.Repo,
+		SHA1:        cmtParams.SHA1,
+		Name:        name,
+		Conclusion:  conclusion,
+		Title:       "exit code: " + strconv.Itoa(cmtParams.ExitCode),
+		Summary:     truncateText(summary, maxCheckRunTextLength),
+		Text:        getCheckRunText(cmtParams.CombinedOutput),
+		Annotations: cmtParams.Annotations,
+	}); err != nil {
+		return fmt.Errorf("create a check run: %w", err)
+	}
+	return nil
+}
+
+// getCheckRunText returns the check run's text.
+// If the output is too long, the head of the output is omitted because the tail is more useful to know the result.
+func getCheckRunText(output string) string {
+	const omitted = "...\n"
+	const fence = "````\n"
+	// 1 is the length of the newline between the output and the closing fence
+	maxLen := maxCheckRunTextLength - len(omitted) - len(fence)*2 - 1
+	if len(output) > maxLen {
+		// skip continuation bytes so that a multi-byte character isn't split
+		start := len(output) - maxLen
+		for start < len(output) && !utf8.RuneStart(output[start]) {
+			start++
+		}
+		output = omitted + output[start:]
+	}
+	return fence + output + "\n" + fence
+}
+
+// truncateText truncates the text to maxLen bytes.
+// The text is truncated at a rune boundary so that a multi-byte character isn't split.
+func truncateText(text string, maxLen int) string {
+	if len(text) <= maxLen {
+		return text
+	}
+	end := maxLen
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return text[:end]
+}
+
+// getReview returns a pull request review.
+// The review body is same as the comment.
+func (c *ExecController) getReview(execConfig *config.ExecConfig, cmt *github.Comment, cmtParams *ExecCommentParams, templates map[string]string) (*github.Review, error) {
+	review := &github.Review{
+		PRNumber: cmt.PRNumber,
+		Org:      cmt.Org,
+		Repo:     cmt.Repo,
+		SHA1:     cmt.SHA1,
+		Body:     cmt.Body,
+		Event:    "COMMENT",
+	}
+	if execConfig.Review == nil {
+		return review, nil
+	}
+	for _, event := range execConfig.Review.Events {
+		f, err := c.Expr.Match(event.When, cmtParams)
+		if err != nil {
+			return nil, fmt.Errorf("test a condition of the review event is matched: %w", err)
+		}
+		if !f {
+			continue
+		}
+		review.Event = event.Event
+		break
+	}
+	switch review.Event {
+	case "APPROVE", "REQUEST_CHANGES", "COMMENT":
+	default:
+		return nil, errors.New("invalid review event. The review event must be either APPROVE, REQUEST_CHANGES, or COMMENT: " + review.Event)
+	}
+	for _, rc := range execConfig.Review.Comments {
+		paramsList, err := c.getReviewCommentParams(rc, cmtParams)
+		if err != nil {
+			return nil, err
+		}
+		for _, params := range paramsList {
+			loc, err := renderReviewCommentLocation(c.Renderer, &rc.ReviewCommentConfig, templates, params)
+			if err != nil {
+				return nil, err
+			}
+			if loc == nil {
+				continue
+			}
+			body, err := c.Renderer.Render(rc.Template, templates, params)
+			if err != nil {
+				return nil, fmt.Errorf("render a review comment template: %w", err)
+			}
+			loc.Body = body
+			review.Comments = append(review.Comments, loc)
+		}
+	}
+	return review, nil
+}
+
+// ReviewCommentParams is the template parameter of an inline review comment generated from `range`.
+// Item is an element of the list which `range` returns.
+type ReviewCommentParams struct {
+	*ExecCommentParams
+
+	Item any
+}
+
+// getReviewCommentParams returns template parameters of inline review comments.
+// If `range` isn't set, a comment is created with cmtParams.
+// Otherwise, a comment is created per element of the list which `range` returns.
+func (c *ExecController) getReviewCommentParams(rc *config.ReviewInlineComment, cmtParams *ExecCommentParams) ([]any, error) {
+	if rc.Range == "" {
+		return []any{cmtParams}, nil
+	}
+	prg, err := c.Expr.CompileAny(rc.Range)
+	if err != nil {
+		return nil, fmt.Errorf("compile the range of the review comment: %w", err)
+	}
+	result, err := prg.Run(cmtParams)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate the range of the review comment: %w", err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("the range of the review comment must return a list: %T", result)
+	}
+	paramsList := make([]any, v.Len())
+	for i := range v.Len() {
+		paramsList[i] = &ReviewCommentParams{
+			ExecCommentParams: cmtParams,
+			Item:              v.Index(i).Interface(),
+		}
+	}
+	return paramsList, nil
+}
+
+func (c *ExecController) handleOutput(
+	ctx context.Context, logger *slog.Logger, execConfig *config.ExecConfig, cmt *github.Comment, cmtParams *ExecCommentParams,
+	templates map[string]string, out *option.Output,
+) error {
+	if out.Review {
+		if cmt.PRNumber == 0 {
+			return errors.New("a pull request review requires a pull request number")
+		}
+		creator, ok := c.GitHub.(ReviewCreator)
+		if !ok {
+			return errors.New("the forge doesn't support pull request reviews")
+		}
+		review, err := c.getReview(execConfig, cmt, cmtParams, templates)
+		if err != nil {
+			return err
+		}
+		if err := creator.CreateReview(ctx, review); err != nil {
+			return fmt.Errorf("create a pull request review: %w", err)
+		}
+		return nil
+	}
 	if out.GitHub {
 		cmtCtrl := CommentController{
 			GitHub: c.GitHub,
 			Expr:   c.Expr,
 			Getenv: c.Getenv,
 		}
-		if err := cmtCtrl.Post(ctx, cmt); err != nil {
+		updateCondition := cmtParams.UpdateCondition
+		if updateCondition == "" {
+			updateCondition = execConfig.UpdateCondition
+		}
+		if cmtParams.progressCommentID != 0 {
+			// the progress comment is finalized
+			updateCondition = ""
+			cmt.CommentID = cmtParams.progressCommentID
+			if len(cmt.Parts) != 0 {
+				cmt.GroupCommentIDs = []int64{cmtParams.progressCommentID}
+			}
+		}
+		if err := cmtCtrl.postWithParam(ctx, logger, c.Config, cmt, &ParamPost{
+			UpdateCondition: updateCondition,
+			Group:           execConfig.Overflow == config.OverflowSplit,
+			HidePrevious:    execConfig.HidePrevious,
+		}); err != nil {
 			return fmt.Errorf("post a comment to GitHub: %w", err)
 		}
 		return nil
@@ -1,11 +1,25 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/execute"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/parser"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/template"
 )
 
 func TestExecController_getExecConfig(t *testing.T) { //nolint:funlen
@@ -77,3 +91,685 @@ func TestExecController_getExecConfig(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestExecController_getReview(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title      string
+		execConfig *config.ExecConfig
+		cmtParams  *ExecCommentParams
+		exp        *github.Review
+		isErr      bool
+	}{
+		{
+			title:      "default event",
+			execConfig: &config.ExecConfig{},
+			cmtParams:  &ExecCommentParams{},
+			exp: &github.Review{
+				PRNumber: 1,
+				Body:     "hello",
+				Event:    "COMMENT",
+			},
+		},
+		{
+			title: "first matched event and inline comments",
+			execConfig: &config.ExecConfig{
+				Review: &config.ReviewConfig{
+					Events: []*config.ReviewEventConfig{
+						{
+							When:  "ExitCode == 0",
+							Event: "APPROVE",
+						},
+						{
+							When:  "ExitCode != 0",
+							Event: "REQUEST_CHANGES",
+						},
+					},
+					Comments: []*config.ReviewInlineComment{
+						{
+							ReviewCommentConfig: config.ReviewCommentConfig{
+								Path: "main.go",
+								Line: "{{.ExitCode}}",
+							},
+							Template: "exit code: {{.ExitCode}}",
+						},
+						{
+							ReviewCommentConfig: config.ReviewCommentConfig{
+								Line: "1",
+							},
+							Template: "skipped",
+						},
+					},
+				},
+			},
+			cmtParams: &ExecCommentParams{
+				ExitCode: 2,
+			},
+			exp: &github.Review{
+				PRNumber: 1,
+				Body:     "hello",
+				Event:    "REQUEST_CHANGES",
+				Comments: []*github.ReviewComment{
+					{
+						Path: "main.go",
+						Line: 2,
+						Body: "exit code: 2",
+					},
+				},
+			},
+		},
+		{
+			title: "range",
+			execConfig: &config.ExecConfig{
+				Review: &config.ReviewConfig{
+					Comments: []*config.ReviewInlineComment{
+						{
+							ReviewCommentConfig: config.ReviewCommentConfig{
+								Path: "{{.Item.Path}}",
+								Line: "{{.Item.StartLine}}",
+							},
+							Range:    "filter(Annotations, .Level != 'notice')",
+							Template: "{{.Item.Message}} (exit code: {{.ExitCode}})",
+						},
+					},
+				},
+			},
+			cmtParams: &ExecCommentParams{
+				ExitCode: 1,
+				Annotations: []*parser.Annotation{
+					{Path: "main.go", StartLine: 3, Level: "failure", Message: "foo"},
+					{Path: "main.go", StartLine: 5, Level: "notice", Message: "bar"},
+					{Path: "cmd/main.go", StartLine: 10, Level: "warning", Message: "baz"},
+				},
+			},
+			exp: &github.Review{
+				PRNumber: 1,
+				Body:     "hello",
+				Event:    "COMMENT",
+				Comments: []*github.ReviewComment{
+					{
+						Path: "main.go",
+						Line: 3,
+						Body: "foo (exit code: 1)",
+					},
+					{
+						Path: "cmd/main.go",
+						Line: 10,
+						Body: "baz (exit code: 1)",
+					},
+				},
+			},
+		},
+		{
+			title: "range is empty",
+			execConfig: &config.ExecConfig{
+				Review: &config.ReviewConfig{
+					Comments: []*config.ReviewInlineComment{
+						{
+							ReviewCommentConfig: config.ReviewCommentConfig{
+								Path: "{{.Item.Path}}",
+								Line: "{{.Item.StartLine}}",
+							},
+							Range:    "Annotations",
+							Template: "{{.Item.Message}}",
+						},
+					},
+				},
+			},
+			cmtParams: &ExecCommentParams{},
+			exp: &github.Review{
+				PRNumber: 1,
+				Body:     "hello",
+				Event:    "COMMENT",
+			},
+		},
+		{
+			title: "range isn't a list",
+			execConfig: &config.ExecConfig{
+				Review: &config.ReviewConfig{
+					Comments: []*config.ReviewInlineComment{
+						{
+							Range:    "ExitCode",
+							Template: "foo",
+						},
+					},
+				},
+			},
+			cmtParams: &ExecCommentParams{},
+			isErr:     true,
+		},
+		{
+			title: "invalid event",
+			execConfig: &config.ExecConfig{
+				Review: &config.ReviewConfig{
+					Events: []*config.ReviewEventConfig{
+						{
+							When:  "true",
+							Event: "foo",
+						},
+					},
+				},
+			},
+			cmtParams: &ExecCommentParams{},
+			isErr:     true,
+		},
+	}
+	ctrl := &ExecController{
+		Expr:     &expr.Expr{},
+		Renderer: &template.Renderer{},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			review, err := ctrl.getReview(d.execConfig, &github.Comment{
+				PRNumber: 1,
+				Body:     "hello",
+			}, d.cmtParams, nil)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, review)
+		})
+	}
+}
+
+func TestExecController_getTimeout(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title string
+		opts  *option.ExecOptions
+		exp   time.Duration
+		isErr bool
+	}{
+		{
+			title: "command line option",
+			opts: &option.ExecOptions{
+				Options: option.Options{TemplateKey: "test"},
+				Timeout: time.Minute,
+			},
+			exp: time.Minute,
+		},
+		{
+			title: "config",
+			opts: &option.ExecOptions{
+				Options: option.Options{TemplateKey: "test"},
+			},
+			exp: 30 * time.Minute,
+		},
+		{
+			title: "no timeout",
+			opts: &option.ExecOptions{
+				Options: option.Options{TemplateKey: "default"},
+			},
+		},
+		{
+			title: "invalid timeout",
+			opts: &option.ExecOptions{
+				Options: option.Options{TemplateKey: "invalid"},
+			},
+			isErr: true,
+		},
+	}
+	ctrl := &ExecController{
+		Config: &config.Config{
+			Exec: map[string][]*config.ExecConfig{
+				"test": {
+					{When: "ExitCode == 0"},
+					{When: "true", Timeout: "30m"},
+				},
+				"invalid": {
+					{When: "true", Timeout: "foo"},
+				},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			timeout, err := ctrl.getTimeout(d.opts)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, timeout)
+		})
+	}
+}
+
+func Test_getExecMetadata(t *testing.T) {
+	t.Parallel()
+	startedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Nil(t, getExecMetadata(&ExecCommentParams{}))
+	require.Equal(t, map[string]any{
+		"ExitCode":   1,
+		"StartedAt":  startedAt,
+		"FinishedAt": startedAt.Add(90 * time.Second),
+		"Duration":   90.0,
+		"MaxRSS":     int64(1024),
+		"UserTime":   1.5,
+		"SystemTime": 0.5,
+	}, getExecMetadata(&ExecCommentParams{
+		ExitCode:   1,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(90 * time.Second),
+		Duration:   90 * time.Second,
+		Rusage: execute.Rusage{
+			MaxRSS:     1024,
+			UserTime:   1500 * time.Millisecond,
+			SystemTime: 500 * time.Millisecond,
+		},
+	}))
+}
+
+// checkRunGitHub records created check runs.
+type checkRunGitHub struct {
+	*github.Mock
+
+	checkRuns []*github.CheckRun
+}
+
+func (g *checkRunGitHub) CreateCheckRun(_ context.Context, checkRun *github.CheckRun) error {
+	g.checkRuns = append(g.checkRuns, checkRun)
+	return nil
+}
+
+func TestExecController_createCheckRun(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	annotations := []*parser.Annotation{
+		{Path: "main.go", StartLine: 1, Level: parser.LevelFailure, Message: "foo"},
+	}
+	data := []struct {
+		title     string
+		cmtParams *ExecCommentParams
+		templates map[string]string
+		exp       *github.CheckRun
+		isErr     bool
+	}{
+		{
+			title: "success",
+			cmtParams: &ExecCommentParams{
+				Org:            "suzuki-shunsuke",
+				Repo:           "github-comment",
+				SHA1:           "abc",
+				CombinedOutput: "hello",
+			},
+			templates: map[string]string{"check_run_summary": "summary"},
+			exp: &github.CheckRun{
+				Org:        "suzuki-shunsuke",
+				Repo:       "github-comment",
+				SHA1:       "abc",
+				Name:       "test",
+				Conclusion: "success",
+				Title:      "exit code: 0",
+				Summary:    "summary",
+				Text:       "````\nhello\n````\n",
+			},
+		},
+		{
+			title: "failure",
+			cmtParams: &ExecCommentParams{
+				SHA1:        "abc",
+				ExitCode:    1,
+				Annotations: annotations,
+			},
+			templates: map[string]string{"check_run_summary": "exit code: {{.ExitCode}}"},
+			exp: &github.CheckRun{
+				SHA1:        "abc",
+				Name:        "test",
+				Conclusion:  "failure",
+				Title:       "exit code: 1",
+				Summary:     "exit code: 1",
+				Text:        "````\n\n````\n",
+				Annotations: annotations,
+			},
+		},
+		{
+			title:     "sha1 is required",
+			cmtParams: &ExecCommentParams{},
+			isErr:     true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &checkRunGitHub{Mock: &github.Mock{}}
+			ctrl := &ExecController{
+				GitHub:   gh,
+				Renderer: &template.Renderer{},
+			}
+			err := ctrl.createCheckRun(context.Background(), d.cmtParams, d.templates, "test")
+			if d.isErr {
+				require.Error(t, err)
+				require.Empty(t, gh.checkRuns)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []*github.CheckRun{d.exp}, gh.checkRuns)
+		})
+	}
+}
+
+// execGitHub records comments and check runs posted by exec.
+type execGitHub struct {
+	*github.Mock
+
+	comments  []*github.IssueComment
+	created   []*github.Comment
+	checkRuns []*github.CheckRun
+}
+
+func (g *execGitHub) ListComments(_ context.Context, _ *github.PullRequest) ([]*github.IssueComment, error) {
+	return g.comments, nil
+}
+
+func (g *execGitHub) CreateComment(_ context.Context, cmt *github.Comment) error {
+	g.created = append(g.created, cmt)
+	return nil
+}
+
+func (g *execGitHub) CreateCheckRun(_ context.Context, checkRun *github.CheckRun) error {
+	g.checkRuns = append(g.checkRuns, checkRun)
+	return nil
+}
+
+func TestExecController_post_parseError(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		parser *config.ParserConfig
+	}{
+		{
+			title: "file isn't found",
+			parser: &config.ParserConfig{
+				Format: "sarif",
+				File:   "result.sarif",
+			},
+		},
+		{
+			title: "invalid output",
+			parser: &config.ParserConfig{
+				Format: "checkstyle",
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &execGitHub{Mock: &github.Mock{}}
+			ctrl := &ExecController{
+				GitHub:   gh,
+				Expr:     &expr.Expr{},
+				Renderer: &template.Renderer{},
+				Fs:       afero.NewMemMapFs(),
+			}
+			err := ctrl.post(context.Background(), logger, []*config.ExecConfig{
+				{
+					When:     "true",
+					Template: "exit code: {{.ExitCode}}",
+					Parser:   d.parser,
+				},
+			}, &ExecCommentParams{
+				ExitCode:       1,
+				CombinedOutput: "panic: foo",
+				Org:            "suzuki-shunsuke",
+				Repo:           "github-comment",
+				PRNumber:       1,
+				SHA1:           "abc",
+				Outputs: []*option.Output{
+					{GitHub: true},
+					{CheckRun: "test"},
+				},
+			}, map[string]string{"check_run_summary": "summary"})
+			require.NoError(t, err)
+			require.Len(t, gh.created, 1)
+			require.True(t, strings.HasPrefix(gh.created[0].Body, "exit code: 1"))
+			require.Len(t, gh.checkRuns, 1)
+			require.Empty(t, gh.checkRuns[0].Annotations)
+		})
+	}
+}
+
+func TestExecController_handleOutput_update(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	newComment := func(databaseID int64, templateKey, login string) *github.IssueComment {
+		cmt := &github.IssueComment{
+			ID:         strconv.FormatInt(databaseID, 10),
+			DatabaseID: databaseID,
+			Body:       `<!-- github-comment: {"TemplateKey":"` + templateKey + `"} -->`,
+		}
+		cmt.Author.Login = login
+		return cmt
+	}
+	comments := []*github.IssueComment{
+		newComment(1, "plan", "octocat"),
+		newComment(2, "apply", "octocat"),
+		// comments posted by other users aren't updated
+		newComment(3, "plan", "other"),
+	}
+	data := []struct {
+		title           string
+		configCondition string
+		optCondition    string
+		expID           int64
+	}{
+		{
+			title:           "update",
+			configCondition: `Comment.Meta.TemplateKey == "plan"`,
+			expID:           1,
+		},
+		{
+			title:           "--update-condition takes precedence over update",
+			configCondition: `Comment.Meta.TemplateKey == "plan"`,
+			optCondition:    `Comment.Meta.TemplateKey == "apply"`,
+			expID:           2,
+		},
+		{
+			title:           "no comment matches",
+			configCondition: `Comment.Meta.TemplateKey == "foo"`,
+		},
+		{
+			title: "update isn't set",
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &execGitHub{
+				Mock:     &github.Mock{Login: "octocat"},
+				comments: comments,
+			}
+			ctrl := &ExecController{
+				GitHub: gh,
+				Expr:   &expr.Expr{},
+				Config: &config.Config{},
+			}
+			err := ctrl.handleOutput(context.Background(), logger, &config.ExecConfig{
+				UpdateCondition: d.configCondition,
+			}, &github.Comment{
+				Org:      "suzuki-shunsuke",
+				Repo:     "github-comment",
+				PRNumber: 1,
+				Body:     "hello",
+			}, &ExecCommentParams{
+				UpdateCondition: d.optCondition,
+			}, nil, &option.Output{GitHub: true})
+			require.NoError(t, err)
+			require.Len(t, gh.created, 1)
+			require.Equal(t, d.expID, gh.created[0].CommentID)
+		})
+	}
+}
+
+// unsupportedGitHub is a forge which supports neither check runs, pull request reviews, nor gists.
+type unsupportedGitHub struct {
+	GitHub
+}
+
+func TestExecController_unsupportedOutputs(t *testing.T) {
+	t.Parallel()
+	ctrl := &ExecController{
+		GitHub:   &unsupportedGitHub{GitHub: &github.Mock{}},
+		Renderer: &template.Renderer{},
+	}
+	cmtParams := &ExecCommentParams{
+		SHA1:           "abc",
+		CombinedOutput: strings.Repeat("a", template.MaxWrapCodeLength+1),
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	require.Error(t, ctrl.createCheckRun(context.Background(), cmtParams, nil, "test"))
+	err := ctrl.handleOutput(context.Background(), logger, &config.ExecConfig{}, &github.Comment{PRNumber: 1}, cmtParams, nil, &option.Output{Review: true})
+	require.Error(t, err)
+	// the comment is posted without the URL
+	ctrl.uploadOverflow(context.Background(), logger, cmtParams)
+	require.Empty(t, cmtParams.OverflowURL)
+}
+
+func Test_truncateText(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		text   string
+		maxLen int
+		exp    string
+	}{
+		{
+			title:  "not truncated",
+			text:   "hello",
+			maxLen: 5,
+			exp:    "hello",
+		},
+		{
+			title:  "truncated",
+			text:   "hello",
+			maxLen: 3,
+			exp:    "hel",
+		},
+		{
+			title:  "a multi-byte character isn't split",
+			text:   "aあい",
+			maxLen: 5,
+			exp:    "aあ",
+		},
+		{
+			title:  "truncated at the head of a multi-byte character",
+			text:   "あい",
+			maxLen: 2,
+			exp:    "",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, d.exp, truncateText(d.text, d.maxLen))
+		})
+	}
+}
+
+func Test_getCheckRunText(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "````\nhello\n````\n", getCheckRunText("hello"))
+	for _, output := range []string{
+		strings.Repeat("a", maxCheckRunTextLength*2),
+		strings.Repeat("あ", maxCheckRunTextLength),
+		"a" + strings.Repeat("あ", maxCheckRunTextLength),
+		"ab" + strings.Repeat("あ", maxCheckRunTextLength),
+	} {
+		text := getCheckRunText(output)
+		require.LessOrEqual(t, len(text), maxCheckRunTextLength)
+		require.True(t, utf8.ValidString(text))
+		require.True(t, strings.HasPrefix(text, "````\n...\n"))
+		require.True(t, strings.HasSuffix(text, output[len(output)-3:]+"\n````\n"))
+	}
+}
+
+// gistGitHub records uploaded gists.
+type gistGitHub struct {
+	*github.Mock
+
+	url   string
+	err   error
+	gists []*github.Gist
+}
+
+func (g *gistGitHub) CreateGist(_ context.Context, gist *github.Gist) (string, error) {
+	g.gists = append(g.gists, gist)
+	return g.url, g.err
+}
+
+func TestExecController_uploadOverflow(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	longOutput := strings.Repeat("a", template.MaxWrapCodeLength+1)
+	data := []struct {
+		title       string
+		output      string
+		uploadErr   error
+		useUploader bool
+		expURL      string
+		expUploaded bool
+	}{
+		{
+			title:  "the output isn't too long",
+			output: strings.Repeat("a", template.MaxWrapCodeLength),
+		},
+		{
+			title:       "upload the output to a gist",
+			output:      longOutput,
+			expURL:      "https://gist.github.com/foo",
+			expUploaded: true,
+		},
+		{
+			title:       "upload the output by the uploader",
+			output:      longOutput,
+			useUploader: true,
+			expURL:      "https://logs.example.com/foo",
+			expUploaded: true,
+		},
+		{
+			title:       "the comment is posted without the URL if it fails to upload the output",
+			output:      longOutput,
+			uploadErr:   errors.New("gist scope is required"),
+			expUploaded: true,
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &gistGitHub{Mock: &github.Mock{}, url: "https://gist.github.com/foo", err: d.uploadErr}
+			uploader := &gistGitHub{Mock: &github.Mock{}, url: "https://logs.example.com/foo"}
+			ctrl := &ExecController{GitHub: gh}
+			if d.useUploader {
+				ctrl.Uploader = uploader
+			}
+			cmtParams := &ExecCommentParams{
+				Org:            "suzuki-shunsuke",
+				Repo:           "github-comment",
+				JoinCommand:    "terraform plan",
+				CombinedOutput: d.output,
+			}
+			ctrl.uploadOverflow(context.Background(), logger, cmtParams)
+			require.Equal(t, d.expURL, cmtParams.OverflowURL)
+			gists := gh.gists
+			if d.useUploader {
+				require.Empty(t, gh.gists)
+				gists = uploader.gists
+			}
+			if !d.expUploaded {
+				require.Empty(t, gists)
+				return
+			}
+			require.Equal(t, []*github.Gist{
+				{
+					Description: "github-comment: suzuki-shunsuke/github-comment $ terraform plan",
+					FileName:    "output.log",
+					Content:     d.output,
+				},
+			}, gists)
+		})
+	}
+}
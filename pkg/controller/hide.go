@@ -7,8 +7,11 @@ import (
 	"io"
 	"log/slog"
 	"maps"
+	"slices"
+	"sync"
 
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
 	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
@@ -34,196 +37,383 @@ func (c *HideController) Hide(ctx context.Context, logger *slog.Logger, opts *op
 	if err != nil {
 		return err
 	}
-	nodeIDs, err := c.listHiddenComments(ctx, logger, param, nil)
+	comments, err := c.listHiddenComments(ctx, logger, param, nil)
 	if err != nil {
 		return err
 	}
 	logger.Debug("comments which would be hidden",
-		"count", len(nodeIDs),
-		"node_ids", nodeIDs,
+		"count", len(comments),
+		"node_ids", hiddenCommentNodeIDs(comments),
 	)
-	c.hideComments(ctx, logger, nodeIDs)
-	return nil
+	parallelism := opts.Parallelism
+	if parallelism == 0 {
+		parallelism = c.Config.HideParallelism
+	}
+	return c.hideComments(ctx, logger, comments, parallelism)
 }
 
-func (c *HideController) getParamListHiddenComments(ctx context.Context, logger *slog.Logger, opts *option.HideOptions) (*ParamListHiddenComments, error) { //nolint:cyclop,funlen
+func (c *HideController) getParamListHiddenComments(ctx context.Context, logger *slog.Logger, opts *option.HideOptions) (*ParamListHiddenComments, error) {
 	param := &ParamListHiddenComments{}
 
-	cfg := c.Config
-
-	if cfg.Base != nil {
-		if opts.Org == "" {
-			opts.Org = cfg.Base.Org
-		}
-		if opts.Repo == "" {
-			opts.Repo = cfg.Base.Repo
-		}
-	}
-
+	var complement func() error
 	if c.Platform != nil {
-		if err := c.Platform.ComplementHide(opts); err != nil {
-			return nil, fmt.Errorf("failed to complement opts with platform built in environment variables: %w", err)
+		complement = func() error {
+			return c.Platform.ComplementHide(opts) //nolint:wrapcheck
 		}
 	}
-
-	if opts.PRNumber == 0 && opts.SHA1 != "" {
-		prNum, err := c.GitHub.PRNumberWithSHA(ctx, opts.Org, opts.Repo, opts.SHA1)
-		if err != nil {
-			slogerr.WithError(logger, err).Warn("list associated prs",
-				"org", opts.Org,
-				"repo", opts.Repo,
-				"sha", opts.SHA1,
-			)
-		}
-		if prNum > 0 {
-			opts.PRNumber = prNum
-		}
+	if err := complementTarget(ctx, logger, c.GitHub, c.Config, &opts.Options, complement); err != nil {
+		return param, err
 	}
-
 	if err := option.ValidateHide(opts); err != nil {
 		return param, fmt.Errorf("opts is invalid: %w", err)
 	}
 
-	hideCondition := opts.Condition
-	if hideCondition == "" {
+	hideConfig := &config.HideConfig{
+		Condition: opts.Condition,
+	}
+	if hideConfig.Condition == "" {
 		a, ok := c.Config.Hide[opts.HideKey]
-		if !ok {
+		if !ok || a == nil {
 			return param, errors.New("invalid hide-key: " + opts.HideKey)
 		}
-		hideCondition = a
+		hideConfig = a
 	}
-
-	if cfg.Vars == nil {
-		cfg.Vars = make(map[string]any, len(opts.Vars))
-	}
-	for k, v := range opts.Vars {
-		cfg.Vars[k] = v
+	if hideConfig.KeepLatest < 0 {
+		return param, errors.New("keep_latest must be greater than or equal to 0")
 	}
 
 	return &ParamListHiddenComments{
-		PRNumber:  opts.PRNumber,
-		Org:       opts.Org,
-		Repo:      opts.Repo,
-		SHA1:      opts.SHA1,
-		Condition: hideCondition,
-		HideKey:   opts.HideKey,
-		Vars:      cfg.Vars,
+		PRNumber:   opts.PRNumber,
+		Org:        opts.Org,
+		Repo:       opts.Repo,
+		SHA1:       opts.SHA1,
+		Condition:  hideConfig.Condition,
+		GroupBy:    hideConfig.GroupBy,
+		KeepLatest: hideConfig.KeepLatest,
+		Classifier: hideConfig.Classifier,
+		HideKey:    opts.HideKey,
+		Vars:       mergeVars(c.Config, opts.Vars),
 	}, nil
 }
 
-func (c *HideController) hideComments(ctx context.Context, logger *slog.Logger, nodeIDs []string) {
-	commentHidden := false
+// DefaultHideParallelism is the default number of comments hidden concurrently.
+const DefaultHideParallelism = 4
+
+// commentAction is an action to comments such as hiding and unhiding.
+type commentAction struct {
+	// verb is used in logs. e.g. hide
+	verb string
+	// pastParticiple is used in logs. e.g. hidden
+	pastParticiple string
+	run            func(ctx context.Context, nodeID string) error
+}
+
+// hiddenComment is a comment which would be hidden.
+type hiddenComment struct {
+	NodeID     string
+	DatabaseID int64
+	Kind       string
+	// Classifier is the reason why the comment is hidden. If Classifier is empty, the default classifier is used
+	Classifier string
+}
+
+func hiddenCommentNodeIDs(comments []*hiddenComment) []string {
+	nodeIDs := make([]string, len(comments))
+	for i, comment := range comments {
+		nodeIDs[i] = comment.NodeID
+	}
+	return nodeIDs
+}
+
+// hideComments hides comments concurrently.
+func (c *HideController) hideComments(ctx context.Context, logger *slog.Logger, comments []*hiddenComment, parallelism int) error {
+	classifiers := make(map[string]string, len(comments))
+	for _, comment := range comments {
+		classifiers[comment.NodeID] = comment.Classifier
+	}
+	return processComments(ctx, logger, hiddenCommentNodeIDs(comments), parallelism, &commentAction{
+		verb:           "hide",
+		pastParticiple: "hidden",
+		run: func(ctx context.Context, nodeID string) error {
+			return c.GitHub.HideComment(ctx, nodeID, classifiers[nodeID])
+		},
+	})
+}
+
+// processComments runs the action to comments concurrently.
+// At most parallelism comments are processed at the same time.
+// If the context is canceled, remaining comments are skipped.
+// Errors are aggregated and returned after all comments are processed.
+func processComments(ctx context.Context, logger *slog.Logger, nodeIDs []string, parallelism int, action *commentAction) error { //nolint:funlen
+	if parallelism <= 0 {
+		parallelism = DefaultHideParallelism
+	}
+	var (
+		mutex     sync.Mutex
+		wg        sync.WaitGroup
+		errs      []error
+		succeeded int
+		skipped   int
+	)
+	semaphore := make(chan struct{}, parallelism)
 	for _, nodeID := range nodeIDs {
-		if err := c.GitHub.HideComment(ctx, nodeID); err != nil {
-			slogerr.WithError(logger, err).Error("hide an old comment",
-				"node_id", nodeID,
-			)
+		if ctx.Err() != nil {
+			skipped++
 			continue
 		}
-		commentHidden = true
-		logger.Info("hide an old comment",
-			"node_id", nodeID,
-		)
+		select {
+		case <-ctx.Done():
+			skipped++
+			continue
+		case semaphore <- struct{}{}:
+		}
+		wg.Go(func() {
+			defer func() {
+				<-semaphore
+			}()
+			err := action.run(ctx, nodeID)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				slogerr.WithError(logger, err).Error(action.verb+" a comment",
+					"node_id", nodeID,
+				)
+				errs = append(errs, fmt.Errorf("%s a comment %s: %w", action.verb, nodeID, err))
+				return
+			}
+			succeeded++
+			logger.Info(action.verb+" a comment",
+				"node_id", nodeID,
+			)
+		})
 	}
-	if !commentHidden {
-		logger.Info("no comment is hidden")
+	wg.Wait()
+	if succeeded == 0 && len(errs) == 0 && skipped == 0 {
+		logger.Info("no comment is " + action.pastParticiple)
+		return nil
 	}
+	logger.Info(action.verb+" comments",
+		action.pastParticiple, succeeded,
+		"failed", len(errs),
+		"skipped", skipped,
+	)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to %s %d comments: %w", action.verb, len(errs), errors.Join(errs...))
+	}
+	if skipped > 0 {
+		return fmt.Errorf("skip %d comments: %w", skipped, ctx.Err())
+	}
+	return nil
 }
 
 type ParamListHiddenComments struct {
 	Condition string
-	HideKey   string
-	Org       string
-	Repo      string
-	SHA1      string
-	PRNumber  int
-	Vars      map[string]any
+	// GroupBy is an expression to group comments. The latest KeepLatest comments in each group aren't hidden
+	GroupBy    string
+	KeepLatest int
+	// Classifier is a classifier name or an expression returning a classifier name
+	Classifier string
+	HideKey    string
+	Org        string
+	Repo       string
+	SHA1       string
+	PRNumber   int
+	Vars       map[string]any
 }
 
-func (c *HideController) listHiddenComments( //nolint:funlen
+func (c *HideController) listHiddenComments(
 	ctx context.Context,
 	logger *slog.Logger,
 	param *ParamListHiddenComments,
 	paramExpr map[string]any,
-) ([]string, error) {
-	if param.Condition == "" {
-		logger.Debug("the condition to hide comments isn't set")
-		return nil, nil
+) ([]*hiddenComment, error) {
+	cmtCtrl := CommentController{
+		GitHub: c.GitHub,
+		Expr:   c.Expr,
+		Getenv: c.Getenv,
 	}
-	login, err := c.GitHub.GetAuthenticatedUser(ctx)
-	if err != nil {
-		slogerr.WithError(logger, err).Warn("get an authenticated user")
+	m := map[string]any{
+		"HideKey": param.HideKey,
 	}
-
-	comments, err := c.GitHub.ListComments(ctx, &github.PullRequest{
-		Org:      param.Org,
-		Repo:     param.Repo,
-		PRNumber: param.PRNumber,
-	})
+	maps.Copy(m, paramExpr)
+	paramList := &ParamListComments{
+		Condition: param.Condition,
+		Org:       param.Org,
+		Repo:      param.Repo,
+		SHA1:      param.SHA1,
+		PRNumber:  param.PRNumber,
+		Vars:      param.Vars,
+		// pull request reviews and review comments can be minimized too
+		IncludeReviews: true,
+	}
+	comments, err := cmtCtrl.listMatchedComments(ctx, logger, paramList, m, isExcludedComment)
 	if err != nil {
-		return nil, err //nolint:wrapcheck
+		return nil, err
 	}
-	logger.Debug("get comments",
-		"count", len(comments),
-		"org", param.Org,
-		"repo", param.Repo,
-		"pr_number", param.PRNumber,
-	)
+	if param.GroupBy != "" || param.KeepLatest > 0 {
+		comments, err = c.excludeLatestComments(logger, comments, param, paramList, m)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.classifyComments(comments, param.Classifier, paramList, m)
+}
 
-	nodeIDs := []string{}
-	prg, err := c.Expr.Compile(param.Condition)
+// classifyComments decides the classifier of each comment.
+// If classifier isn't a classifier name, it is evaluated as an expression per comment.
+func (c *HideController) classifyComments(
+	comments []*github.IssueComment,
+	classifier string,
+	paramList *ParamListComments,
+	paramExpr map[string]any,
+) ([]*hiddenComment, error) {
+	hidden := make([]*hiddenComment, len(comments))
+	if classifier == "" || github.IsClassifier(classifier) {
+		for i, comment := range comments {
+			hidden[i] = &hiddenComment{
+				NodeID:     comment.ID,
+				DatabaseID: comment.DatabaseID,
+				Kind:       comment.Kind,
+				Classifier: classifier,
+			}
+		}
+		return hidden, nil
+	}
+	prg, err := c.Expr.CompileAny(classifier)
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
-	for _, comment := range comments {
-		nodeID := comment.ID
-		// TODO remove these filters
-		if isExcludedComment(comment, login) {
-			logger.Debug("exclude a comment",
-				"node_id", nodeID,
-				"login", login,
-			)
-			continue
+	for i, comment := range comments {
+		v, err := prg.Run(newCommentParam(comment, paramList, paramExpr))
+		if err != nil {
+			return nil, fmt.Errorf("evaluate classifier: %w", err)
 		}
-
-		metadata := map[string]any{}
-		hasMeta := extractMetaFromComment(comment.Body, &metadata)
-		paramMap := map[string]any{
-			"Comment": map[string]any{
-				"Body": comment.Body,
-				// "CreatedAt": comment.CreatedAt,
-				"Meta":    metadata,
-				"HasMeta": hasMeta,
-			},
-			"Commit": map[string]any{
-				"Org":      param.Org,
-				"Repo":     param.Repo,
-				"PRNumber": param.PRNumber,
-				"SHA1":     param.SHA1,
-			},
-			"HideKey": param.HideKey,
-			"Vars":    param.Vars,
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("the result of classifier must be a string: %v", v)
 		}
-		maps.Copy(paramMap, paramExpr)
+		if err := github.ValidateClassifier(s); err != nil {
+			return nil, fmt.Errorf("evaluate classifier: %w", err)
+		}
+		hidden[i] = &hiddenComment{
+			NodeID:     comment.ID,
+			DatabaseID: comment.DatabaseID,
+			Kind:       comment.Kind,
+			Classifier: s,
+		}
+	}
+	return hidden, nil
+}
 
-		logger.Debug("judge whether an existing comment is hidden",
-			"node_id", nodeID,
-			"condition", param.Condition,
-			"param", paramMap,
-		)
-		f, err := prg.Run(paramMap)
+// excludeLatestComments groups comments by the expression GroupBy and excludes the latest KeepLatest comments in each group.
+// If GroupBy is empty, all comments belong to the same group.
+func (c *HideController) excludeLatestComments(
+	logger *slog.Logger,
+	comments []*github.IssueComment,
+	param *ParamListHiddenComments,
+	paramList *ParamListComments,
+	paramExpr map[string]any,
+) ([]*github.IssueComment, error) {
+	var prg expr.AnyProgram
+	if param.GroupBy != "" {
+		p, err := c.Expr.CompileAny(param.GroupBy)
 		if err != nil {
-			slogerr.WithError(logger, err).Error("judge whether an existing comment is hidden",
-				"node_id", nodeID,
-			)
-			continue
+			return nil, err //nolint:wrapcheck
 		}
-		if !f {
-			continue
+		prg = p
+	}
+	// pull request reviews are listed after issue comments, so comments are sorted by the creation time
+	sorted := slices.Clone(comments)
+	slices.SortStableFunc(sorted, func(a, b *github.IssueComment) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	groups := map[string][]*github.IssueComment{}
+	keys := []string{}
+	for _, comment := range sorted {
+		key := ""
+		if prg != nil {
+			v, err := prg.Run(newCommentParam(comment, paramList, paramExpr))
+			if err != nil {
+				return nil, fmt.Errorf("evaluate group_by: %w", err)
+			}
+			key = fmt.Sprint(v)
 		}
-		nodeIDs = append(nodeIDs, nodeID)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], comment)
+	}
+	hidden := []*github.IssueComment{}
+	for _, key := range keys {
+		group := groups[key]
+		n := max(len(group)-param.KeepLatest, 0)
+		logger.Debug("keep the latest comments",
+			"group", key,
+			"count", len(group),
+			"kept", len(group)-n,
+		)
+		hidden = append(hidden, group[:n]...)
 	}
-	return nodeIDs, nil
+	return hidden, nil
+}
+
+// Unhide unhides comments which were hidden and match with the hide condition.
+// The condition is selected in the same way as Hide, but group_by and keep_latest are ignored.
+func (c *HideController) Unhide(ctx context.Context, logger *slog.Logger, opts *option.HideOptions) error {
+	param, err := c.getParamListHiddenComments(ctx, logger, opts)
+	if err != nil {
+		return err
+	}
+	cmtCtrl := CommentController{
+		GitHub: c.GitHub,
+		Expr:   c.Expr,
+		Getenv: c.Getenv,
+	}
+	comments, err := cmtCtrl.listMatchedComments(ctx, logger, &ParamListComments{
+		Condition:      param.Condition,
+		Org:            param.Org,
+		Repo:           param.Repo,
+		SHA1:           param.SHA1,
+		PRNumber:       param.PRNumber,
+		Vars:           param.Vars,
+		IncludeReviews: true,
+	}, map[string]any{
+		"HideKey": param.HideKey,
+	}, isExcludedUnhiddenComment)
+	if err != nil {
+		return err
+	}
+	nodeIDs := make([]string, len(comments))
+	for i, comment := range comments {
+		nodeIDs[i] = comment.ID
+	}
+	logger.Debug("comments which would be unhidden",
+		"count", len(nodeIDs),
+		"node_ids", nodeIDs,
+	)
+	parallelism := opts.Parallelism
+	if parallelism == 0 {
+		parallelism = c.Config.HideParallelism
+	}
+	return processComments(ctx, logger, nodeIDs, parallelism, &commentAction{
+		verb:           "unhide",
+		pastParticiple: "unhidden",
+		run:            c.GitHub.UnhideComment,
+	})
+}
+
+// isExcludedUnhiddenComment excludes comments which aren't hidden or were posted by other users.
+func isExcludedUnhiddenComment(cmt *github.IssueComment, login string) bool {
+	if !cmt.ViewerCanMinimize {
+		return true
+	}
+	if !cmt.IsMinimized {
+		return true
+	}
+	if login != "" && cmt.Author.Login != login {
+		return true
+	}
+	return false
 }
 
 func isExcludedComment(cmt *github.IssueComment, login string) bool {
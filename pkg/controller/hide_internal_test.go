@@ -0,0 +1,380 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+)
+
+// hideGitHub records hidden comments and the maximum number of concurrent requests.
+type hideGitHub struct {
+	*github.Mock
+
+	mutex         sync.Mutex
+	comments      []*github.IssueComment
+	hidden        []string
+	classifiers   map[string]string
+	running       int
+	maxConcurrent int
+	failed        map[string]struct{}
+}
+
+func (g *hideGitHub) ListComments(_ context.Context, _ *github.PullRequest) ([]*github.IssueComment, error) {
+	return g.comments, nil
+}
+
+func (g *hideGitHub) HideComment(_ context.Context, nodeID, classifier string) error {
+	g.mutex.Lock()
+	g.running++
+	g.maxConcurrent = max(g.maxConcurrent, g.running)
+	g.mutex.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.running--
+	if _, ok := g.failed[nodeID]; ok {
+		return errors.New("failed to hide a comment")
+	}
+	g.hidden = append(g.hidden, nodeID)
+	if g.classifiers != nil {
+		g.classifiers[nodeID] = classifier
+	}
+	return nil
+}
+
+func TestHideController_hideComments(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title       string
+		nodeIDs     []string
+		failed      map[string]struct{}
+		parallelism int
+		isErr       bool
+		expHidden   int
+	}{
+		{
+			title:       "normal",
+			nodeIDs:     []string{"a", "b", "c", "d", "e"},
+			parallelism: 2,
+			expHidden:   5,
+		},
+		{
+			title:       "errors are aggregated",
+			nodeIDs:     []string{"a", "b", "c", "d", "e"},
+			failed:      map[string]struct{}{"b": {}, "d": {}},
+			parallelism: 3,
+			isErr:       true,
+			expHidden:   3,
+		},
+		{
+			title: "no comment",
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &hideGitHub{failed: d.failed}
+			ctrl := &HideController{GitHub: gh}
+			comments := make([]*hiddenComment, len(d.nodeIDs))
+			for i, nodeID := range d.nodeIDs {
+				comments[i] = &hiddenComment{NodeID: nodeID}
+			}
+			err := ctrl.hideComments(context.Background(), logger, comments, d.parallelism)
+			if d.isErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, gh.hidden, d.expHidden)
+			require.LessOrEqual(t, gh.maxConcurrent, d.parallelism)
+		})
+	}
+}
+
+func TestHideController_hideComments_canceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	gh := &hideGitHub{}
+	ctrl := &HideController{GitHub: gh}
+	err := ctrl.hideComments(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), []*hiddenComment{{NodeID: "a"}, {NodeID: "b"}}, 1)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, gh.hidden)
+}
+
+func TestHideController_excludeLatestComments(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newComment := func(id, templateKey string, minutes int) *github.IssueComment {
+		return &github.IssueComment{
+			ID:        id,
+			Body:      `<!-- github-comment: {"TemplateKey":"` + templateKey + `"} -->`,
+			CreatedAt: now.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+	comments := []*github.IssueComment{
+		newComment("a", "plan", 1),
+		newComment("b", "apply", 2),
+		newComment("c", "plan", 3),
+		// a review is listed after issue comments
+		newComment("d", "plan", 0),
+	}
+	data := []struct {
+		title string
+		param *ParamListHiddenComments
+		exp   []string
+	}{
+		{
+			title: "keep the latest comment",
+			param: &ParamListHiddenComments{
+				KeepLatest: 1,
+			},
+			exp: []string{"d", "a", "b"},
+		},
+		{
+			title: "keep the latest comment per template key",
+			param: &ParamListHiddenComments{
+				GroupBy:    "Comment.Meta.TemplateKey",
+				KeepLatest: 1,
+			},
+			exp: []string{"d", "a"},
+		},
+		{
+			title: "keep the latest two comments per template key",
+			param: &ParamListHiddenComments{
+				GroupBy:    "Comment.Meta.TemplateKey",
+				KeepLatest: 2,
+			},
+			exp: []string{"d"},
+		},
+		{
+			title: "group_by without keep_latest",
+			param: &ParamListHiddenComments{
+				GroupBy: "Comment.Meta.TemplateKey",
+			},
+			exp: []string{"d", "a", "c", "b"},
+		},
+	}
+	ctrl := &HideController{Expr: &expr.Expr{}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			hidden, err := ctrl.excludeLatestComments(logger, comments, d.param, &ParamListComments{}, nil)
+			require.NoError(t, err)
+			ids := make([]string, len(hidden))
+			for i, cmt := range hidden {
+				ids[i] = cmt.ID
+			}
+			require.Equal(t, d.exp, ids)
+		})
+	}
+}
+
+func Test_isExcludedUnhiddenComment(t *testing.T) {
+	t.Parallel()
+	newComment := func(login string, isMinimized, viewerCanMinimize bool) *github.IssueComment {
+		cmt := &github.IssueComment{
+			IsMinimized:       isMinimized,
+			ViewerCanMinimize: viewerCanMinimize,
+		}
+		cmt.Author.Login = login
+		return cmt
+	}
+	data := []struct {
+		title   string
+		comment *github.IssueComment
+		login   string
+		exp     bool
+	}{
+		{
+			title:   "hidden comment",
+			comment: newComment("octocat", true, true),
+			login:   "octocat",
+		},
+		{
+			title:   "comment isn't hidden",
+			comment: newComment("octocat", false, true),
+			login:   "octocat",
+			exp:     true,
+		},
+		{
+			title:   "viewer can't minimize",
+			comment: newComment("octocat", true, false),
+			login:   "octocat",
+			exp:     true,
+		},
+		{
+			title:   "other user's comment",
+			comment: newComment("foo", true, true),
+			login:   "octocat",
+			exp:     true,
+		},
+		{
+			title:   "login is empty",
+			comment: newComment("foo", true, true),
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, d.exp, isExcludedUnhiddenComment(d.comment, d.login))
+		})
+	}
+}
+
+func TestHideController_classifyComments(t *testing.T) {
+	t.Parallel()
+	comments := []*github.IssueComment{
+		{
+			ID:   "a",
+			Body: `<!-- github-comment: {"TemplateKey":"plan"} -->`,
+		},
+		{
+			ID:   "b",
+			Body: `<!-- github-comment: {"TemplateKey":"apply"} -->`,
+		},
+	}
+	data := []struct {
+		title      string
+		classifier string
+		isErr      bool
+		exp        []*hiddenComment
+	}{
+		{
+			title: "default",
+			exp:   []*hiddenComment{{NodeID: "a"}, {NodeID: "b"}},
+		},
+		{
+			title:      "classifier name",
+			classifier: "resolved",
+			exp:        []*hiddenComment{{NodeID: "a", Classifier: "resolved"}, {NodeID: "b", Classifier: "resolved"}},
+		},
+		{
+			title:      "expression",
+			classifier: `Comment.Meta.TemplateKey == "plan" ? "OUTDATED" : "DUPLICATE"`,
+			exp:        []*hiddenComment{{NodeID: "a", Classifier: "OUTDATED"}, {NodeID: "b", Classifier: "DUPLICATE"}},
+		},
+		{
+			title:      "invalid classifier",
+			classifier: `"foo"`,
+			isErr:      true,
+		},
+		{
+			title:      "not a string",
+			classifier: "1",
+			isErr:      true,
+		},
+	}
+	ctrl := &HideController{Expr: &expr.Expr{}}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			hidden, err := ctrl.classifyComments(comments, d.classifier, &ParamListComments{}, nil)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, hidden)
+		})
+	}
+}
+
+func TestHideController_Hide(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	newComment := func(id, templateKey string) *github.IssueComment {
+		cmt := &github.IssueComment{
+			ID:                id,
+			Body:              `<!-- github-comment: {"TemplateKey":"` + templateKey + `"} -->`,
+			ViewerCanMinimize: true,
+		}
+		cmt.Author.Login = "octocat"
+		return cmt
+	}
+	comments := []*github.IssueComment{
+		newComment("a", "plan"),
+		newComment("b", "apply"),
+	}
+	data := []struct {
+		title     string
+		hideKey   string
+		condition string
+		exp       map[string]string
+	}{
+		{
+			title:   "default classifier",
+			hideKey: "default",
+			exp:     map[string]string{"a": "", "b": ""},
+		},
+		{
+			title:   "classifier name",
+			hideKey: "resolved",
+			exp:     map[string]string{"a": "RESOLVED", "b": "RESOLVED"},
+		},
+		{
+			title:   "classifier expression",
+			hideKey: "expression",
+			exp:     map[string]string{"a": "OUTDATED", "b": "DUPLICATE"},
+		},
+		{
+			title:     "condition",
+			hideKey:   "resolved",
+			condition: "true",
+			exp:       map[string]string{"a": "", "b": ""},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &hideGitHub{
+				Mock:        &github.Mock{Login: "octocat"},
+				comments:    comments,
+				classifiers: map[string]string{},
+			}
+			ctrl := &HideController{
+				GitHub: gh,
+				Expr:   &expr.Expr{},
+				Config: &config.Config{
+					Hide: map[string]*config.HideConfig{
+						"default": {
+							Condition: "true",
+						},
+						"resolved": {
+							Condition:  "true",
+							Classifier: "RESOLVED",
+						},
+						"expression": {
+							Condition:  "true",
+							Classifier: `Comment.Meta.TemplateKey == "plan" ? "OUTDATED" : "DUPLICATE"`,
+						},
+					},
+				},
+			}
+			err := ctrl.Hide(context.Background(), logger, &option.HideOptions{
+				Options: option.Options{
+					Org:      "suzuki-shunsuke",
+					Repo:     "github-comment",
+					PRNumber: 1,
+					Token:    "xxx",
+				},
+				HideKey:   d.hideKey,
+				Condition: d.condition,
+			})
+			require.NoError(t, err)
+			require.Equal(t, d.exp, gh.classifiers)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// ParamPost is the parameter to post a comment.
+type ParamPost struct {
+	// UpdateCondition updates the latest comment which matches with the condition instead of creating a new comment.
+	UpdateCondition string
+	// Group is true if a group of split comments is updated.
+	Group bool
+	// HidePrevious is a key of hide or a condition.
+	// Previous comments which match with it are hidden after the comment is posted.
+	HidePrevious string
+}
+
+// postWithParam posts the comment.
+// If param.HidePrevious is set, previous comments are listed before the comment is posted and hidden after the comment is posted.
+// So the posted comment is never hidden.
+// Comments of the pull request are listed only once and shared with param.UpdateCondition.
+func (c *CommentController) postWithParam(ctx context.Context, logger *slog.Logger, cfg *config.Config, cmt *github.Comment, param *ParamPost) error {
+	ctrl := *c
+	hidePrevious := param.HidePrevious != "" && cmt.PRNumber != 0
+	var (
+		hideCtrl *HideController
+		previous []*hiddenComment
+	)
+	if hidePrevious {
+		ctrl.GitHub = &commentCache{GitHub: c.GitHub}
+		hideCtrl = &HideController{
+			GitHub: ctrl.GitHub,
+			Expr:   c.Expr,
+			Getenv: c.Getenv,
+			Config: cfg,
+		}
+		p, err := newParamHidePrevious(cfg, cmt, param.HidePrevious)
+		if err != nil {
+			return err
+		}
+		cmts, err := hideCtrl.listHiddenComments(ctx, logger, p, nil)
+		if err != nil {
+			return fmt.Errorf("list previous comments to hide: %w", err)
+		}
+		previous = cmts
+	}
+	if param.UpdateCondition != "" && cmt.PRNumber != 0 {
+		if err := ctrl.setUpdatedCommentID(ctx, logger, cmt, param.UpdateCondition, param.Group); err != nil {
+			return err
+		}
+	}
+	if err := ctrl.Post(ctx, cmt); err != nil {
+		return err
+	}
+	if !hidePrevious {
+		return nil
+	}
+	previous = excludeUpdatedComments(previous, cmt)
+	logger.Debug("previous comments which would be hidden",
+		"count", len(previous),
+		"node_ids", hiddenCommentNodeIDs(previous),
+	)
+	if err := hideCtrl.hideComments(ctx, logger, previous, cfg.HideParallelism); err != nil {
+		return fmt.Errorf("hide previous comments: %w", err)
+	}
+	return nil
+}
+
+// newParamHidePrevious returns the parameter to list previous comments.
+// If hidePrevious is a key of hide, the hide config is used. Otherwise hidePrevious is treated as a condition.
+func newParamHidePrevious(cfg *config.Config, cmt *github.Comment, hidePrevious string) (*ParamListHiddenComments, error) {
+	hideConfig := &config.HideConfig{
+		Condition: hidePrevious,
+	}
+	hideKey := ""
+	if a, ok := cfg.Hide[hidePrevious]; ok && a != nil {
+		hideConfig = a
+		hideKey = hidePrevious
+	}
+	if hideConfig.KeepLatest < 0 {
+		return nil, errors.New("keep_latest must be greater than or equal to 0")
+	}
+	return &ParamListHiddenComments{
+		Condition:  hideConfig.Condition,
+		GroupBy:    hideConfig.GroupBy,
+		KeepLatest: hideConfig.KeepLatest,
+		Classifier: hideConfig.Classifier,
+		HideKey:    hideKey,
+		Org:        cmt.Org,
+		Repo:       cmt.Repo,
+		SHA1:       cmt.SHA1,
+		PRNumber:   cmt.PRNumber,
+		Vars:       cmt.Vars,
+	}, nil
+}
+
+// excludeUpdatedComments excludes comments updated by the posted comment.
+func excludeUpdatedComments(comments []*hiddenComment, cmt *github.Comment) []*hiddenComment {
+	if cmt.CommentID == 0 && len(cmt.GroupCommentIDs) == 0 {
+		return comments
+	}
+	return slices.DeleteFunc(comments, func(comment *hiddenComment) bool {
+		if isReview(comment.Kind) {
+			return false
+		}
+		return comment.DatabaseID == cmt.CommentID || slices.Contains(cmt.GroupCommentIDs, comment.DatabaseID)
+	})
+}
+
+// isReview returns true if the comment is a pull request review or a review comment.
+func isReview(kind string) bool {
+	return kind == github.CommentKindPullRequestReview || kind == github.CommentKindPullRequestReviewComment
+}
+
+// commentCache lists comments of the pull request only once.
+// Comments are listed with pull request reviews and review comments,
+// and they are filtered out if they aren't requested.
+// commentCache isn't goroutine safe.
+type commentCache struct {
+	GitHub
+
+	comments []*github.IssueComment
+	listed   bool
+	login    string
+	loginErr error
+	gotLogin bool
+}
+
+func (c *commentCache) ListComments(ctx context.Context, pr *github.PullRequest) ([]*github.IssueComment, error) {
+	if !c.listed {
+		p := *pr
+		p.IncludeReviews = true
+		comments, err := c.GitHub.ListComments(ctx, &p)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		c.comments = comments
+		c.listed = true
+	}
+	if pr.IncludeReviews {
+		return c.comments, nil
+	}
+	comments := make([]*github.IssueComment, 0, len(c.comments))
+	for _, comment := range c.comments {
+		if !isReview(comment.Kind) {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (c *commentCache) GetAuthenticatedUser(ctx context.Context) (string, error) {
+	if !c.gotLogin {
+		c.login, c.loginErr = c.GitHub.GetAuthenticatedUser(ctx)
+		c.gotLogin = true
+	}
+	return c.login, c.loginErr
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// hidePreviousGitHub records API calls to post a comment and hide previous comments.
+type hidePreviousGitHub struct {
+	*github.Mock
+
+	mutex    sync.Mutex
+	comments []*github.IssueComment
+	listed   int
+	created  []*github.Comment
+	hidden   map[string]string
+}
+
+func (g *hidePreviousGitHub) ListComments(_ context.Context, pr *github.PullRequest) ([]*github.IssueComment, error) {
+	g.listed++
+	if pr.IncludeReviews {
+		return g.comments, nil
+	}
+	comments := []*github.IssueComment{}
+	for _, comment := range g.comments {
+		if comment.Kind == github.CommentKindIssueComment {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (g *hidePreviousGitHub) CreateComment(_ context.Context, cmt *github.Comment) error {
+	g.created = append(g.created, cmt)
+	return nil
+}
+
+func (g *hidePreviousGitHub) HideComment(_ context.Context, nodeID, classifier string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.hidden[nodeID] = classifier
+	return nil
+}
+
+func TestCommentController_postWithParam(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	newComment := func(id string, databaseID int64, kind, templateKey string) *github.IssueComment {
+		cmt := &github.IssueComment{
+			ID:                id,
+			DatabaseID:        databaseID,
+			Kind:              kind,
+			Body:              `<!-- github-comment: {"TemplateKey":"` + templateKey + `"} -->`,
+			ViewerCanMinimize: true,
+		}
+		cmt.Author.Login = "octocat"
+		return cmt
+	}
+	comments := []*github.IssueComment{
+		newComment("a", 1, github.CommentKindIssueComment, "plan"),
+		newComment("b", 2, github.CommentKindIssueComment, "apply"),
+		newComment("c", 3, github.CommentKindIssueComment, "plan"),
+		newComment("d", 1, github.CommentKindPullRequestReview, "plan"),
+	}
+	cfg := &config.Config{
+		Hide: map[string]*config.HideConfig{
+			"plan": {
+				Condition:  `Comment.Meta.TemplateKey == "plan"`,
+				Classifier: "RESOLVED",
+			},
+		},
+	}
+	data := []struct {
+		title     string
+		param     *ParamPost
+		expListed int
+		expHidden map[string]string
+		expID     int64
+	}{
+		{
+			title:     "hide key",
+			param:     &ParamPost{HidePrevious: "plan"},
+			expListed: 1,
+			expHidden: map[string]string{"a": "RESOLVED", "c": "RESOLVED", "d": "RESOLVED"},
+		},
+		{
+			title:     "condition",
+			param:     &ParamPost{HidePrevious: `Comment.Meta.TemplateKey == "apply"`},
+			expListed: 1,
+			expHidden: map[string]string{"b": ""},
+		},
+		{
+			title: "the updated comment isn't hidden",
+			param: &ParamPost{
+				UpdateCondition: `Comment.Meta.TemplateKey == "plan"`,
+				HidePrevious:    "plan",
+			},
+			expListed: 1,
+			expHidden: map[string]string{"a": "RESOLVED", "d": "RESOLVED"},
+			expID:     3,
+		},
+		{
+			title:     "hide_previous isn't set",
+			param:     &ParamPost{},
+			expHidden: map[string]string{},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gh := &hidePreviousGitHub{
+				Mock:     &github.Mock{Login: "octocat"},
+				comments: comments,
+				hidden:   map[string]string{},
+			}
+			ctrl := &CommentController{
+				GitHub: gh,
+				Expr:   &expr.Expr{},
+			}
+			cmt := &github.Comment{
+				Org:      "suzuki-shunsuke",
+				Repo:     "github-comment",
+				PRNumber: 1,
+				Body:     "hello",
+			}
+			require.NoError(t, ctrl.postWithParam(t.Context(), logger, cfg, cmt, d.param))
+			require.Equal(t, d.expListed, gh.listed)
+			require.Len(t, gh.created, 1)
+			require.Equal(t, d.expID, gh.created[0].CommentID)
+			require.Equal(t, d.expHidden, gh.hidden)
+		})
+	}
+}
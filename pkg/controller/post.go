@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -32,7 +33,7 @@ type PostController struct {
 }
 
 func (c *PostController) Post(ctx context.Context, logger *slog.Logger, opts *option.PostOptions) error {
-	cmt, err := c.getCommentParams(ctx, logger, opts)
+	cmt, param, err := c.getCommentParams(ctx, logger, opts)
 	if err != nil {
 		return err
 	}
@@ -48,79 +49,7 @@ func (c *PostController) Post(ctx context.Context, logger *slog.Logger, opts *op
 		Expr:   c.Expr,
 		Getenv: c.Getenv,
 	}
-	return cmtCtrl.Post(ctx, cmt)
-}
-
-func (c *PostController) setUpdatedCommentID(ctx context.Context, logger *slog.Logger, cmt *github.Comment, updateCondition string) error { //nolint:funlen
-	prg, err := c.Expr.Compile(updateCondition)
-	if err != nil {
-		return err //nolint:wrapcheck
-	}
-
-	login, err := c.GitHub.GetAuthenticatedUser(ctx)
-	if err != nil {
-		slogerr.WithError(logger, err).Warn("get an authenticated user")
-	}
-
-	comments, err := c.GitHub.ListComments(ctx, &github.PullRequest{
-		Org:      cmt.Org,
-		Repo:     cmt.Repo,
-		PRNumber: cmt.PRNumber,
-	})
-	if err != nil {
-		return fmt.Errorf("list issue or pull request comments: %w", err)
-	}
-	logger.Debug("get comments",
-		"org", cmt.Org,
-		"repo", cmt.Repo,
-		"pr_number", cmt.PRNumber,
-	)
-
-	for _, comnt := range comments {
-		if comnt.IsMinimized {
-			// ignore minimized comments
-			continue
-		}
-		if login != "" && comnt.Author.Login != login {
-			// ignore other users' comments
-			continue
-		}
-
-		metadata := map[string]any{}
-		hasMeta := extractMetaFromComment(comnt.Body, &metadata)
-		paramMap := map[string]any{
-			"Comment": map[string]any{
-				"Body":    comnt.Body,
-				"Meta":    metadata,
-				"HasMeta": hasMeta,
-			},
-			"Commit": map[string]any{
-				"Org":      cmt.Org,
-				"Repo":     cmt.Repo,
-				"PRNumber": cmt.PRNumber,
-				"SHA1":     cmt.SHA1,
-			},
-			"Vars": cmt.Vars,
-		}
-
-		logger.Debug("judge whether an existing comment is ready for editing",
-			"node_id", comnt.ID,
-			"condition", updateCondition,
-			"param", paramMap,
-		)
-		f, err := prg.Run(paramMap)
-		if err != nil {
-			slogerr.WithError(logger, err).Error("judge whether an existing comment is hidden",
-				"node_id", comnt.ID,
-			)
-			continue
-		}
-		if !f {
-			continue
-		}
-		cmt.CommentID = comnt.DatabaseID
-	}
-	return nil
+	return cmtCtrl.postWithParam(ctx, logger, c.Config, cmt, param)
 }
 
 // Reader is API to find and read the configuration file of github-comment
@@ -130,6 +59,7 @@ type Reader interface {
 
 type Renderer interface {
 	Render(tpl string, templates map[string]string, params any) (string, error)
+	RenderWithoutTruncation(tpl string, templates map[string]string, params any) (string, error)
 }
 
 type PostTemplateParams struct {
@@ -149,10 +79,12 @@ type Platform interface {
 	ComplementPost(opts *option.PostOptions) error
 	ComplementExec(opts *option.ExecOptions) error
 	ComplementHide(opts *option.HideOptions) error
+	ComplementDelete(opts *option.DeleteOptions) error
 	CI() string
 }
 
-func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logger, opts *option.PostOptions) (*github.Comment, error) { //nolint:funlen,cyclop,gocognit
+// getCommentParams returns the comment and the parameter to post it.
+func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logger, opts *option.PostOptions) (*github.Comment, *ParamPost, error) { //nolint:funlen,cyclop,gocognit
 	cfg := c.Config
 
 	if cfg.Base != nil {
@@ -165,7 +97,7 @@ func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logg
 	}
 	if c.Platform != nil {
 		if err := c.Platform.ComplementPost(opts); err != nil {
-			return nil, fmt.Errorf("failed to complement opts with platform built in environment variables: %w", err)
+			return nil, nil, fmt.Errorf("failed to complement opts with platform built in environment variables: %w", err)
 		}
 	}
 
@@ -186,20 +118,26 @@ func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logg
 	if opts.Template == "" && opts.StdinTemplate {
 		tpl, err := c.readTemplateFromStdin()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		opts.Template = tpl
 	}
 
 	if err := option.ValidatePost(opts); err != nil {
-		return nil, fmt.Errorf("opts is invalid: %w", err)
+		return nil, nil, fmt.Errorf("opts is invalid: %w", err)
 	}
 
+	var reviewComment *config.ReviewCommentConfig
+	overflow := ""
+	hidePrevious := ""
 	if opts.Template == "" {
 		tpl, err := c.readTemplateFromConfig(cfg, opts.TemplateKey)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		reviewComment = tpl.ReviewComment
+		overflow = tpl.Overflow
+		hidePrevious = tpl.HidePrevious
 		opts.Template = tpl.Template
 		opts.TemplateForTooLong = tpl.TemplateForTooLong
 		opts.EmbeddedVarNames = tpl.EmbeddedVarNames
@@ -223,27 +161,25 @@ func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logg
 		Templates: cfg.Templates,
 		CI:        ci,
 	})
-	tpl, err := c.Renderer.Render(opts.Template, templates, PostTemplateParams{
+	tplParams := PostTemplateParams{
 		PRNumber:    opts.PRNumber,
 		Org:         opts.Org,
 		Repo:        opts.Repo,
 		SHA1:        opts.SHA1,
 		TemplateKey: opts.TemplateKey,
 		Vars:        cfg.Vars,
-	})
+	}
+	render := c.Renderer.Render
+	if overflow == config.OverflowSplit {
+		render = c.Renderer.RenderWithoutTruncation
+	}
+	tpl, err := render(opts.Template, templates, tplParams)
 	if err != nil {
-		return nil, fmt.Errorf("render a template for post: %w", err)
+		return nil, nil, fmt.Errorf("render a template for post: %w", err)
 	}
-	tplForTooLong, err := c.Renderer.Render(opts.TemplateForTooLong, templates, PostTemplateParams{
-		PRNumber:    opts.PRNumber,
-		Org:         opts.Org,
-		Repo:        opts.Repo,
-		SHA1:        opts.SHA1,
-		TemplateKey: opts.TemplateKey,
-		Vars:        cfg.Vars,
-	})
+	tplForTooLong, err := c.Renderer.Render(opts.TemplateForTooLong, templates, tplParams)
 	if err != nil {
-		return nil, fmt.Errorf("render a template template_for_too_long for post: %w", err)
+		return nil, nil, fmt.Errorf("render a template template_for_too_long for post: %w", err)
 	}
 
 	cmtCtrl := CommentController{
@@ -258,15 +194,24 @@ func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logg
 			embeddedMetadata[name] = v
 		}
 	}
-	embeddedComment, err := cmtCtrl.getEmbeddedComment(map[string]any{
+	data := map[string]any{
 		"SHA1":        opts.SHA1,
 		"TemplateKey": opts.TemplateKey,
 		"Vars":        embeddedMetadata,
-	})
+	}
+	if overflow == config.OverflowSplit {
+		// GroupID distinguishes split comments of this run from comments of other runs
+		data["GroupID"] = rand.Text()
+	}
+	embeddedComment, err := cmtCtrl.getEmbeddedComment(data)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	var parts []string
+	if overflow == config.OverflowSplit {
+		parts = splitComment(tpl, embeddedComment)
+	}
 	tpl += embeddedComment
 	tplForTooLong += embeddedComment
 
@@ -279,13 +224,18 @@ func (c *PostController) getCommentParams(ctx context.Context, logger *slog.Logg
 		SHA1:           opts.SHA1,
 		Vars:           cfg.Vars,
 		TemplateKey:    opts.TemplateKey,
+		Parts:          parts,
 	}
-	if opts.UpdateCondition != "" && opts.PRNumber != 0 {
-		if err := c.setUpdatedCommentID(ctx, logger, cmt, opts.UpdateCondition); err != nil {
-			return nil, err
+	if opts.PRNumber != 0 {
+		if err := setReviewComment(c.Renderer, reviewComment, templates, tplParams, cmt); err != nil {
+			return nil, nil, err
 		}
 	}
-	return cmt, nil
+	return cmt, &ParamPost{
+		UpdateCondition: opts.UpdateCondition,
+		Group:           overflow == config.OverflowSplit,
+		HidePrevious:    hidePrevious,
+	}, nil
 }
 
 func (c *PostController) readTemplateFromStdin() (string, error) {
@@ -118,6 +118,58 @@ func TestPostController_getCommentParams(t *testing.T) { //nolint:funlen
 				Vars:        map[string]any{},
 			},
 		},
+		{
+			title: "review comment",
+			ctrl: &PostController{
+				HasStdin: func() bool {
+					return false
+				},
+				Getenv: func(_ string) string {
+					return ""
+				},
+				Config: &config.Config{
+					Vars: map[string]any{
+						"file": "main.go",
+					},
+					Post: map[string]*config.PostConfig{
+						"default": {
+							Template: "hello",
+							ReviewComment: &config.ReviewCommentConfig{
+								Path: "{{.Vars.file}}",
+								Line: "10",
+								Side: "RIGHT",
+							},
+						},
+					},
+				},
+				Renderer: &template.Renderer{
+					Getenv: func(_ string) string {
+						return ""
+					},
+				},
+			},
+			opts: &option.PostOptions{
+				Options: option.Options{
+					Org:         "suzuki-shunsuke",
+					Repo:        "github-comment",
+					Token:       "xxx",
+					TemplateKey: "default",
+					PRNumber:    1,
+				},
+			},
+			exp: &github.Comment{
+				Org:         "suzuki-shunsuke",
+				Repo:        "github-comment",
+				PRNumber:    1,
+				TemplateKey: "default",
+				Vars: map[string]any{
+					"file": "main.go",
+				},
+				Path: "main.go",
+				Line: 10,
+				Side: "RIGHT",
+			},
+		},
 		{
 			title: "template is rendered properly",
 			ctrl: &PostController{
@@ -190,7 +242,7 @@ func TestPostController_getCommentParams(t *testing.T) { //nolint:funlen
 		t.Run(d.title, func(t *testing.T) {
 			t.Parallel()
 			ctx := t.Context()
-			cmt, err := d.ctrl.getCommentParams(ctx, slog.Default(), d.opts)
+			cmt, _, err := d.ctrl.getCommentParams(ctx, slog.Default(), d.opts)
 			if d.isErr {
 				require.Error(t, err)
 				return
@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/template"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// DefaultProgressInterval is the default interval to update the progress comment.
+const DefaultProgressInterval = 30 * time.Second
+
+// progressTailLines is the number of lines of the command output passed to running_template.
+const progressTailLines = 30
+
+// defaultRunningTemplate is used if running_template isn't set.
+const defaultRunningTemplate = `:hourglass_flowing_sand: Running {{template "link" .}}
+
+{{template "join_command" .}}
+
+{{template "hidden_combined_output" .}}`
+
+// maxProgressLineLength is the maximum length of a line kept in progressOutput.
+// If a line is longer than this, the head of the line is dropped.
+const maxProgressLineLength = 4096
+
+// progressOutput keeps the last lines of the command output while the command is running.
+// Only the last lines are kept so that the memory usage is bounded even if the output is huge.
+type progressOutput struct {
+	mutex sync.Mutex
+	// lines is a ring buffer of the last complete lines
+	lines []string
+	// next is the index of lines where the next line is stored
+	next int
+	// count is the number of lines stored in lines
+	count int
+	// partial is the last line which doesn't end with a newline yet
+	partial []byte
+}
+
+// newProgressOutput returns a progressOutput which keeps the last n lines.
+func newProgressOutput(n int) *progressOutput {
+	return &progressOutput{
+		lines: make([]string, n),
+	}
+}
+
+func (p *progressOutput) Write(b []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	n := len(b)
+	for {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			p.partial = append(p.partial, b...)
+			if len(p.partial) > maxProgressLineLength {
+				p.partial = p.partial[len(p.partial)-maxProgressLineLength:]
+			}
+			return n, nil
+		}
+		p.addLine(string(append(p.partial, b[:i]...)))
+		p.partial = p.partial[:0]
+		b = b[i+1:]
+	}
+}
+
+func (p *progressOutput) addLine(line string) {
+	if len(p.lines) == 0 {
+		return
+	}
+	if len(line) > maxProgressLineLength {
+		line = line[len(line)-maxProgressLineLength:]
+	}
+	p.lines[p.next] = line
+	p.next = (p.next + 1) % len(p.lines)
+	p.count = min(p.count+1, len(p.lines))
+}
+
+// Tail returns the kept lines of the output.
+// Trailing newlines are trimmed.
+func (p *progressOutput) Tail() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	lines := make([]string, 0, p.count+1)
+	start := (p.next - p.count + len(p.lines)) % max(len(p.lines), 1)
+	for i := range p.count {
+		lines = append(lines, p.lines[(start+i)%len(p.lines)])
+	}
+	if len(p.partial) != 0 {
+		lines = append(lines, string(p.partial))
+	}
+	lines = lines[max(len(lines)-len(p.lines), 0):]
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// progress posts a comment before the command is run and updates it while the command is running.
+type progress struct {
+	ctrl            *ExecController
+	logger          *slog.Logger
+	runningTemplate string
+	templates       map[string]string
+	cmtParams       *ExecCommentParams
+	embeddedComment string
+	output          *progressOutput
+	cmt             *github.Comment
+	lastBody        string
+	cancel          context.CancelFunc
+	done            chan struct{}
+}
+
+// startProgress posts the progress comment and starts updating it periodically.
+// If the progress comment can't be posted, nil is returned and the command is run without the progress comment.
+func (c *ExecController) startProgress(ctx context.Context, logger *slog.Logger, opts *option.ExecOptions) *progress { //nolint:funlen
+	if !hasGitHubOutput(opts.Outputs) {
+		return nil
+	}
+	if opts.PRNumber == 0 {
+		logger.Warn("the progress comment isn't posted because the pull request number isn't found")
+		return nil
+	}
+	execConfigs, err := c.getExecConfigs(c.Config, opts)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("get config to post the progress comment")
+		return nil
+	}
+	ci := ""
+	if c.Platform != nil {
+		ci = c.Platform.CI()
+	}
+	joinCommand := strings.Join(opts.Args, " ")
+	vars := make(map[string]any, len(c.Config.Vars)+len(opts.Vars))
+	for k, v := range c.Config.Vars {
+		vars[k] = v
+	}
+	for k, v := range opts.Vars {
+		vars[k] = v
+	}
+	progressID := rand.Text()
+	cmtCtrl := CommentController{
+		GitHub:   c.GitHub,
+		Expr:     c.Expr,
+		Getenv:   c.Getenv,
+		Platform: c.Platform,
+	}
+	embeddedComment, err := cmtCtrl.getEmbeddedComment(map[string]any{
+		"SHA1":        opts.SHA1,
+		"TemplateKey": opts.TemplateKey,
+		"ProgressID":  progressID,
+	})
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("embed metadata to the progress comment")
+		return nil
+	}
+	p := &progress{
+		ctrl:            c,
+		logger:          logger,
+		runningTemplate: getRunningTemplate(execConfigs),
+		templates: template.GetTemplates(&template.ParamGetTemplates{
+			Templates:   c.Config.Templates,
+			CI:          ci,
+			JoinCommand: joinCommand,
+		}),
+		cmtParams: &ExecCommentParams{
+			Command:     joinCommand,
+			JoinCommand: joinCommand,
+			PRNumber:    opts.PRNumber,
+			Org:         opts.Org,
+			Repo:        opts.Repo,
+			SHA1:        opts.SHA1,
+			TemplateKey: opts.TemplateKey,
+			Vars:        vars,
+		},
+		embeddedComment: embeddedComment,
+		output:          newProgressOutput(progressTailLines),
+		cmt: &github.Comment{
+			PRNumber:    opts.PRNumber,
+			Org:         opts.Org,
+			Repo:        opts.Repo,
+			SHA1:        opts.SHA1,
+			Vars:        vars,
+			TemplateKey: opts.TemplateKey,
+		},
+		done: make(chan struct{}),
+	}
+	if err := p.update(ctx); err != nil {
+		slogerr.WithError(logger, err).Warn("post the progress comment")
+		return nil
+	}
+	// CreateComment doesn't return the comment id, so the posted comment is found by the unique id in the metadata
+	if err := cmtCtrl.setUpdatedCommentID(ctx, logger, p.cmt, `Comment.HasMeta && Comment.Meta.ProgressID == "`+progressID+`"`, false); err != nil {
+		slogerr.WithError(logger, err).Warn("find the progress comment")
+		return nil
+	}
+	if p.cmt.CommentID == 0 {
+		logger.Warn("the progress comment isn't updated because it isn't found")
+		return nil
+	}
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+	go p.run(ctx, interval)
+	return p
+}
+
+func hasGitHubOutput(outputs []*option.Output) bool {
+	for _, out := range outputs {
+		if out.GitHub {
+			return true
+		}
+	}
+	return false
+}
+
+// getRunningTemplate returns the first running_template of ExecConfigs.
+// ExecConfig can't be selected by `when` before the command is run.
+func getRunningTemplate(execConfigs []*config.ExecConfig) string {
+	for _, execConfig := range execConfigs {
+		if execConfig.RunningTemplate != "" {
+			return execConfig.RunningTemplate
+		}
+	}
+	return defaultRunningTemplate
+}
+
+// run updates the progress comment periodically until the context is canceled.
+func (p *progress) run(ctx context.Context, interval time.Duration) {
+	defer close(p.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.update(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				slogerr.WithError(p.logger, err).Warn("update the progress comment")
+			}
+		}
+	}
+}
+
+// update renders running_template with the tail of the command output and posts it.
+// If the comment isn't changed, it isn't posted.
+func (p *progress) update(ctx context.Context) error {
+	p.cmtParams.CombinedOutput = p.output.Tail()
+	body, err := p.ctrl.Renderer.Render(p.runningTemplate, p.templates, p.cmtParams)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if body == p.lastBody {
+		return nil
+	}
+	p.cmt.Body = body + p.embeddedComment
+	p.cmt.BodyForTooLong = p.cmt.Body
+	if err := p.ctrl.GitHub.CreateComment(ctx, p.cmt); err != nil {
+		return err //nolint:wrapcheck
+	}
+	p.lastBody = body
+	return nil
+}
+
+// stop stops updating the progress comment and returns the id of the progress comment.
+func (p *progress) stop() int64 {
+	p.cancel()
+	<-p.done
+	return p.cmt.CommentID
+}
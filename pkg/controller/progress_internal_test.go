@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/config"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/expr"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/template"
+)
+
+// progressGitHub stores posted comments so that the progress comment can be found.
+type progressGitHub struct {
+	*github.Mock
+
+	mutex    sync.Mutex
+	comments []*github.IssueComment
+	edited   int
+}
+
+func (g *progressGitHub) CreateComment(_ context.Context, cmt *github.Comment) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if cmt.CommentID != 0 {
+		for _, comment := range g.comments {
+			if comment.DatabaseID == cmt.CommentID {
+				comment.Body = cmt.Body
+			}
+		}
+		g.edited++
+		return nil
+	}
+	g.comments = append(g.comments, &github.IssueComment{
+		ID:         "node",
+		DatabaseID: int64(len(g.comments) + 1),
+		Kind:       github.CommentKindIssueComment,
+		Body:       cmt.Body,
+	})
+	return nil
+}
+
+func (g *progressGitHub) ListComments(_ context.Context, _ *github.PullRequest) ([]*github.IssueComment, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.comments, nil
+}
+
+func Test_progressOutput(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title  string
+		n      int
+		writes []string
+		exp    string
+	}{
+		{
+			title:  "short",
+			n:      3,
+			writes: []string{"a\nb\n"},
+			exp:    "a\nb",
+		},
+		{
+			title:  "long",
+			n:      2,
+			writes: []string{"a\nb\nc\nd\n"},
+			exp:    "c\nd",
+		},
+		{
+			title: "empty",
+			n:     2,
+		},
+		{
+			title:  "lines are split across writes",
+			n:      2,
+			writes: []string{"a\nb", "c\nd", "e\nf"},
+			exp:    "de\nf",
+		},
+		{
+			title:  "the ring buffer wraps around",
+			n:      3,
+			writes: []string{"1\n2\n", "3\n4\n5\n", "6\n7\n"},
+			exp:    "5\n6\n7",
+		},
+		{
+			title:  "a too long line is truncated",
+			n:      2,
+			writes: []string{strings.Repeat("a", maxProgressLineLength) + "b\n"},
+			exp:    strings.Repeat("a", maxProgressLineLength-1) + "b",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			output := newProgressOutput(d.n)
+			for _, w := range d.writes {
+				n, err := output.Write([]byte(w))
+				require.NoError(t, err)
+				require.Equal(t, len(w), n)
+			}
+			require.Equal(t, d.exp, output.Tail())
+		})
+	}
+}
+
+func TestExecController_startProgress(t *testing.T) {
+	t.Parallel()
+	gh := &progressGitHub{Mock: &github.Mock{}}
+	ctrl := &ExecController{
+		GitHub: gh,
+		Renderer: &template.Renderer{
+			Getenv: func(string) string { return "" },
+		},
+		Expr:   &expr.Expr{},
+		Getenv: func(string) string { return "" },
+		Config: &config.Config{
+			Exec: map[string][]*config.ExecConfig{
+				"test": {
+					{
+						When:            "true",
+						Template:        "done",
+						RunningTemplate: "running {{.JoinCommand}}\n{{.CombinedOutput}}",
+					},
+				},
+			},
+		},
+	}
+	opts := &option.ExecOptions{
+		Options: option.Options{
+			Org:         "suzuki-shunsuke",
+			Repo:        "github-comment",
+			PRNumber:    1,
+			TemplateKey: "test",
+		},
+		Args:    []string{"echo", "hello"},
+		Outputs: []*option.Output{{GitHub: true}},
+	}
+	prog := ctrl.startProgress(t.Context(), slog.New(slog.NewTextHandler(io.Discard, nil)), opts)
+	require.NotNil(t, prog)
+	require.Len(t, gh.comments, 1)
+	require.Contains(t, gh.comments[0].Body, "running echo hello\n")
+
+	_, err := prog.output.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	require.NoError(t, prog.update(t.Context()))
+	require.Equal(t, 1, gh.edited)
+	require.Contains(t, gh.comments[0].Body, "running echo hello\nhello")
+	// the comment isn't edited if it isn't changed
+	require.NoError(t, prog.update(t.Context()))
+	require.Equal(t, 1, gh.edited)
+	require.Equal(t, int64(1), prog.stop())
+}
@@ -3,10 +3,12 @@ package execute
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"syscall"
 	"time"
 
 	"github.com/mattn/go-colorable"
@@ -24,24 +26,90 @@ type Result struct {
 	Stdout         string
 	Stderr         string
 	CombinedOutput string
+	// TimedOut is true if the command was stopped because of the timeout
+	TimedOut bool
+	// Signal is the name of the signal which terminated the command. e.g. interrupt
+	// If the command wasn't terminated by a signal, Signal is empty.
+	Signal string
+	// Duration is the elapsed time of the command
+	Duration time.Duration
+	// StartedAt is the time when the command started
+	StartedAt time.Time
+	// FinishedAt is the time when the command finished
+	FinishedAt time.Time
+	// Rusage is the resource usage of the command.
+	// If the resource usage isn't available, Rusage is zero.
+	Rusage Rusage
+}
+
+// Rusage is the resource usage of the command.
+type Rusage struct {
+	// MaxRSS is the maximum resident set size in bytes.
+	// MaxRSS is 0 on platforms where it isn't available such as Windows.
+	MaxRSS int64
+	// UserTime is the user CPU time
+	UserTime time.Duration
+	// SystemTime is the system CPU time
+	SystemTime time.Duration
+}
+
+// getRusage returns the resource usage of the process.
+func getRusage(state *os.ProcessState) Rusage {
+	if state == nil {
+		return Rusage{}
+	}
+	return Rusage{
+		MaxRSS:     maxRSS(state),
+		UserTime:   state.UserTime(),
+		SystemTime: state.SystemTime(),
+	}
 }
 
 type Params struct {
 	Cmd   string
 	Args  []string
 	Stdin io.Reader
+	// Output receives the uncolorized standard output and standard error output of the command.
+	// Output must be goroutine safe. Output is optional.
+	Output io.Writer
+	// Timeout is the timeout of the command. If Timeout is zero, the command doesn't time out.
+	// When the command times out, SIGINT is sent to the command, and then SIGKILL is sent after killDelay.
+	Timeout time.Duration
 }
 
 const waitDelay = 1000 * time.Hour
 
-func setCancel(cmd *exec.Cmd) {
+// killDelay is the duration to wait for the command to exit after SIGINT is sent by the timeout.
+const killDelay = 30 * time.Second
+
+func setCancel(cmd *exec.Cmd, hasTimeout bool) {
 	cmd.Cancel = func() error {
 		return cmd.Process.Signal(os.Interrupt)
 	}
 	cmd.WaitDelay = waitDelay
+	if hasTimeout {
+		cmd.WaitDelay = killDelay
+	}
+}
+
+// getSignal returns the name of the signal which terminated the process.
+func getSignal(state *os.ProcessState) string {
+	if state == nil {
+		return ""
+	}
+	ws, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !ws.Signaled() {
+		return ""
+	}
+	return ws.Signal().String()
 }
 
 func (e *Executor) Run(ctx context.Context, params *Params) (*Result, error) {
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
 	cmd := exec.CommandContext(ctx, params.Cmd, params.Args...) //nolint:gosec
 	cmd.Stdin = params.Stdin
 	stdout := &bytes.Buffer{}
@@ -50,12 +118,21 @@ func (e *Executor) Run(ctx context.Context, params *Params) (*Result, error) {
 	uncolorizedStdout := colorable.NewNonColorable(stdout)
 	uncolorizedStderr := colorable.NewNonColorable(stderr)
 	uncolorizedCombinedOutput := colorable.NewNonColorable(combinedOutput)
-	cmd.Stdout = io.MultiWriter(e.Stdout, uncolorizedStdout, uncolorizedCombinedOutput)
-	cmd.Stderr = io.MultiWriter(e.Stderr, uncolorizedStderr, uncolorizedCombinedOutput)
+	stdoutWriters := []io.Writer{e.Stdout, uncolorizedStdout, uncolorizedCombinedOutput}
+	stderrWriters := []io.Writer{e.Stderr, uncolorizedStderr, uncolorizedCombinedOutput}
+	if params.Output != nil {
+		uncolorizedOutput := colorable.NewNonColorable(params.Output)
+		stdoutWriters = append(stdoutWriters, uncolorizedOutput)
+		stderrWriters = append(stderrWriters, uncolorizedOutput)
+	}
+	cmd.Stdout = io.MultiWriter(stdoutWriters...)
+	cmd.Stderr = io.MultiWriter(stderrWriters...)
 	cmd.Env = e.Env
 
-	setCancel(cmd)
+	setCancel(cmd, params.Timeout > 0)
+	startedAt := time.Now()
 	err := cmd.Run()
+	finishedAt := time.Now()
 
 	ec := cmd.ProcessState.ExitCode()
 	result := &Result{
@@ -64,6 +141,12 @@ func (e *Executor) Run(ctx context.Context, params *Params) (*Result, error) {
 		Stdout:         stdout.String(),
 		Stderr:         stderr.String(),
 		CombinedOutput: combinedOutput.String(),
+		TimedOut:       params.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded),
+		Signal:         getSignal(cmd.ProcessState),
+		Duration:       finishedAt.Sub(startedAt),
+		StartedAt:      startedAt,
+		FinishedAt:     finishedAt,
+		Rusage:         getRusage(cmd.ProcessState),
 	}
 	if err == nil {
 		return result, nil
@@ -0,0 +1,83 @@
+//go:build !windows
+
+package execute
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_Run(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title       string
+		params      *Params
+		isErr       bool
+		expExitCode int
+		expTimedOut bool
+		expSignal   string
+	}{
+		{
+			title: "normal",
+			params: &Params{
+				Cmd:     "true",
+				Timeout: time.Minute,
+			},
+		},
+		{
+			title: "failure",
+			params: &Params{
+				Cmd: "false",
+			},
+			isErr:       true,
+			expExitCode: 1,
+		},
+		{
+			title: "timeout",
+			params: &Params{
+				Cmd:     "sleep",
+				Args:    []string{"10"},
+				Timeout: 100 * time.Millisecond,
+			},
+			isErr:       true,
+			expExitCode: -1,
+			expTimedOut: true,
+			expSignal:   "interrupt",
+		},
+		{
+			title: "the command handles SIGINT and exits with 0 after the timeout",
+			params: &Params{
+				Cmd:     "sh",
+				Args:    []string{"-c", `trap "exit 0" INT; while :; do sleep 0.05; done`},
+				Timeout: 100 * time.Millisecond,
+			},
+			// exec.Cmd returns the context error even if the command exits with 0
+			isErr:       true,
+			expTimedOut: true,
+		},
+	}
+	e := &Executor{
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			result, err := e.Run(t.Context(), d.params)
+			if d.isErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, d.expExitCode, result.ExitCode)
+			require.Equal(t, d.expTimedOut, result.TimedOut)
+			require.Equal(t, d.expSignal, result.Signal)
+			require.Positive(t, result.Duration)
+			require.Equal(t, result.Duration, result.FinishedAt.Sub(result.StartedAt))
+			require.Positive(t, result.Rusage.MaxRSS)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package execute
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRSS returns the maximum resident set size in bytes.
+// ru_maxrss is in bytes on macOS.
+func maxRSS(state *os.ProcessState) int64 {
+	ru, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return 0
+	}
+	return ru.Maxrss
+}
@@ -0,0 +1,18 @@
+//go:build unix && !darwin
+
+package execute
+
+import (
+	"os"
+	"syscall"
+)
+
+// maxRSS returns the maximum resident set size in bytes.
+// ru_maxrss is in kilobytes on Linux and BSD.
+func maxRSS(state *os.ProcessState) int64 {
+	ru, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || ru == nil {
+		return 0
+	}
+	return int64(ru.Maxrss) * 1024 //nolint:mnd,unconvert
+}
@@ -0,0 +1,10 @@
+package execute
+
+import (
+	"os"
+)
+
+// maxRSS returns 0 because the maximum resident set size isn't available on Windows.
+func maxRSS(_ *os.ProcessState) int64 {
+	return 0
+}
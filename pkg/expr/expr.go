@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
@@ -9,8 +10,26 @@ import (
 
 type Expr struct{}
 
+// functions returns custom functions available in expressions.
+// expr-lang's builtin functions such as now() and duration() are also available.
+func functions() []expr.Option {
+	return []expr.Option{
+		// age returns the elapsed time since the given time.
+		// e.g. age(Comment.CreatedAt) > duration("24h")
+		expr.Function("age", age, new(func(time.Time) time.Duration)),
+	}
+}
+
+func age(params ...any) (any, error) {
+	t, ok := params[0].(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("the argument of age must be time.Time: %T", params[0])
+	}
+	return time.Since(t), nil
+}
+
 func (*Expr) Match(expression string, params any) (bool, error) {
-	prog, err := expr.Compile(expression, expr.Env(params), expr.AsBool())
+	prog, err := expr.Compile(expression, append(functions(), expr.Env(params), expr.AsBool())...)
 	if err != nil {
 		return false, fmt.Errorf("compile an expression: %s: %w", expression, err)
 	}
@@ -30,7 +49,7 @@ type Program interface {
 
 func (*Expr) Compile(expression string) (Program, error) {
 	prog := Prog{}
-	prg, err := expr.Compile(expression, expr.AsBool())
+	prg, err := expr.Compile(expression, append(functions(), expr.AsBool())...)
 	if err != nil {
 		return &prog, fmt.Errorf("compile an expression: "+expression+": %w", err)
 	}
@@ -52,3 +71,30 @@ func (p *Prog) Run(params any) (bool, error) {
 	}
 	return true, nil
 }
+
+type AnyProgram interface {
+	Run(params any) (any, error)
+}
+
+// CompileAny compiles an expression whose result can be any type.
+func (*Expr) CompileAny(expression string) (AnyProgram, error) {
+	prog := AnyProg{}
+	prg, err := expr.Compile(expression, functions()...)
+	if err != nil {
+		return &prog, fmt.Errorf("compile an expression: "+expression+": %w", err)
+	}
+	prog.prg = prg
+	return &prog, nil
+}
+
+type AnyProg struct {
+	prg *vm.Program
+}
+
+func (p *AnyProg) Run(params any) (any, error) {
+	output, err := expr.Run(p.prg, params)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate an expression with params: %w", err)
+	}
+	return output, nil
+}
@@ -0,0 +1,45 @@
+package expr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpr_Compile(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title      string
+		expression string
+		params     map[string]any
+		exp        bool
+	}{
+		{
+			title:      "old comment",
+			expression: `age(Comment.CreatedAt) > duration("24h")`,
+			params: map[string]any{
+				"Comment": map[string]any{"CreatedAt": time.Now().Add(-48 * time.Hour)},
+			},
+			exp: true,
+		},
+		{
+			title:      "new comment",
+			expression: `age(Comment.CreatedAt) > duration("24h")`,
+			params: map[string]any{
+				"Comment": map[string]any{"CreatedAt": time.Now().Add(-time.Hour)},
+			},
+		},
+	}
+	e := &Expr{}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			prog, err := e.Compile(d.expression)
+			require.NoError(t, err)
+			f, err := prog.Run(d.params)
+			require.NoError(t, err)
+			require.Equal(t, d.exp, f)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package gitea
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/rest"
+)
+
+// Client is a Gitea (and Forgejo) API client.
+// Client implements the same API as GitHub client.
+type Client struct {
+	rest   *rest.Client
+	logger *slog.Logger
+}
+
+type ParamNew struct {
+	Token string
+	// BaseURL is the base URL of Gitea API. e.g. https://gitea.example.com/api/v1
+	BaseURL string
+	// Retry is the configuration to retry requests. If Retry is nil, requests aren't retried
+	Retry  *github.Retry
+	Logger *slog.Logger
+}
+
+func New(param *ParamNew) (*Client, error) {
+	if param.BaseURL == "" {
+		return nil, errors.New("the base URL of Gitea API is required")
+	}
+	return &Client{
+		rest: rest.New(&rest.ParamNew{
+			Name:    "Gitea",
+			BaseURL: param.BaseURL,
+			Header: http.Header{
+				"Authorization": []string{"token " + param.Token},
+				"Accept":        []string{"application/json"},
+			},
+			Retry:  param.Retry,
+			Logger: param.Logger,
+		}),
+		logger: param.Logger,
+	}, nil
+}
+
+func repoPath(org, repo string) string {
+	return "repos/" + org + "/" + repo
+}
@@ -0,0 +1,176 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// server is a local stand-in of Gitea API which stores comments of pull requests in memory.
+type server struct {
+	mu       sync.Mutex
+	comments map[int64]*comment
+	nextID   int64
+	// prs maps commit SHAs to pull request numbers
+	prs map[string]int
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) { //nolint:cyclop
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("Authorization") != "token xxx" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/repos/foo/bar/")
+	switch {
+	case r.Method == http.MethodPost && path == "issues/1/comments":
+		s.nextID++
+		cmt := &comment{ID: s.nextID, Body: readBody(r)}
+		cmt.User.Login = "octocat"
+		s.comments[cmt.ID] = cmt
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(cmt)
+	case r.Method == http.MethodGet && path == "issues/1/comments":
+		// Like the real API, limit and page are ignored and all comments are returned.
+		cmts := []*comment{}
+		for id := int64(1); id <= s.nextID; id++ {
+			if cmt, ok := s.comments[id]; ok {
+				cmts = append(cmts, cmt)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(cmts)
+	case strings.HasPrefix(path, "issues/comments/"):
+		id, _ := strconv.ParseInt(strings.TrimPrefix(path, "issues/comments/"), 10, 64)
+		cmt, ok := s.comments[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPatch:
+			cmt.Body = readBody(r)
+		case http.MethodDelete:
+			delete(s.comments, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(cmt)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "commits/"):
+		num, ok := s.prs[strings.TrimSuffix(strings.TrimPrefix(path, "commits/"), "/pull")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]int{"number": num})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func readBody(r *http.Request) string {
+	body := map[string]string{}
+	b, _ := io.ReadAll(r.Body)
+	_ = json.Unmarshal(b, &body)
+	return body["body"]
+}
+
+func newTestClient(t *testing.T, s *server) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	client, err := New(&ParamNew{
+		Token:   "xxx",
+		BaseURL: srv.URL + "/api/v1",
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := &server{
+		comments: map[int64]*comment{},
+		prs:      map[string]int{"abc": 1},
+	}
+	client := newTestClient(t, s)
+
+	prNum, err := client.PRNumberWithSHA(ctx, "foo", "bar", "abc")
+	require.NoError(t, err)
+	require.Equal(t, 1, prNum)
+	prNum, err = client.PRNumberWithSHA(ctx, "foo", "bar", "def")
+	require.NoError(t, err)
+	require.Equal(t, 0, prNum)
+
+	cmt := &github.Comment{Org: "foo", Repo: "bar", PRNumber: 1, Body: "hello"}
+	require.NoError(t, client.CreateComment(ctx, cmt))
+	cmt.Body = "world"
+	require.NoError(t, client.CreateComment(ctx, cmt))
+	cmt.CommentID = 2
+	cmt.Body = "updated"
+	require.NoError(t, client.CreateComment(ctx, cmt))
+
+	pr := &github.PullRequest{Org: "foo", Repo: "bar", PRNumber: 1}
+	comments, err := client.ListComments(ctx, pr)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	require.Equal(t, "hello", comments[0].Body)
+	require.Equal(t, "updated", comments[1].Body)
+	require.Equal(t, "octocat", comments[0].Author.Login)
+	require.False(t, comments[0].IsMinimized)
+
+	// hide the first comment
+	require.NoError(t, client.HideComment(ctx, comments[0].ID, ""))
+	// hiding a hidden comment does nothing
+	require.NoError(t, client.HideComment(ctx, comments[0].ID, ""))
+	comments, err = client.ListComments(ctx, pr)
+	require.NoError(t, err)
+	require.True(t, comments[0].IsMinimized)
+	require.Equal(t, wrapOutdated("hello"), comments[0].Body)
+	require.Equal(t, "outdated", comments[0].MinimizedReason)
+	require.False(t, comments[1].IsMinimized)
+
+	// unhide the first comment
+	require.NoError(t, client.UnhideComment(ctx, comments[0].ID))
+	comments, err = client.ListComments(ctx, pr)
+	require.NoError(t, err)
+	require.False(t, comments[0].IsMinimized)
+	require.Equal(t, "hello", comments[0].Body)
+
+	require.NoError(t, client.DeleteComment(ctx, pr, 2))
+	comments, err = client.ListComments(ctx, pr)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	require.Error(t, client.CreateComment(ctx, &github.Comment{Org: "foo", Repo: "bar", SHA1: "abc", Body: "hello"}))
+}
+
+func TestClient_ListComments_many(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := &server{
+		comments: map[int64]*comment{},
+	}
+	for range 120 {
+		s.nextID++
+		s.comments[s.nextID] = &comment{ID: s.nextID, Body: "hello"}
+	}
+	client := newTestClient(t, s)
+	comments, err := client.ListComments(ctx, &github.PullRequest{Org: "foo", Repo: "bar", PRNumber: 1})
+	require.NoError(t, err)
+	require.Len(t, comments, 120)
+	require.Equal(t, int64(120), comments[119].DatabaseID)
+}
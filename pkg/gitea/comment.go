@@ -0,0 +1,169 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// maxCommentLength is the maximum length of a comment body.
+// Gitea doesn't limit the length, but the limit of GitHub is used to keep comments readable.
+const maxCommentLength = github.MaxCommentLength
+
+// outdatedMarker is added to the comment hidden by github-comment.
+// Gitea doesn't support minimizing comments, so a hidden comment is wrapped in <details>.
+const outdatedMarker = "<!-- github-comment-outdated -->"
+
+type comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+	HTMLURL   string    `json:"html_url"`
+}
+
+func commentPath(org, repo string, id int64) string {
+	return repoPath(org, repo) + "/issues/comments/" + strconv.FormatInt(id, 10)
+}
+
+// CreateComment creates or updates a comment of the pull request.
+// Gitea doesn't support commit comments, so the pull request number is required.
+// Review comments aren't supported, so the comment is posted to the pull request conversation.
+func (c *Client) CreateComment(ctx context.Context, cmt *github.Comment) error {
+	if cmt.PRNumber == 0 {
+		return errors.New("commit comments aren't supported in Gitea. A pull request number is required")
+	}
+	if cmt.Path != "" {
+		c.logger.Warn("Gitea doesn't support review comments, so the comment is posted to the pull request conversation",
+			"path", cmt.Path,
+			"line", cmt.Line,
+		)
+	}
+	body := cmt.Body
+	if len(body) > maxCommentLength {
+		c.logger.Warn("body is too long so it is replaced with `BodyForTooLong`",
+			"body_length", len(body),
+		)
+		body = cmt.BodyForTooLong
+	}
+	if cmt.CommentID != 0 {
+		if _, err := c.rest.Do(ctx, http.MethodPatch, commentPath(cmt.Org, cmt.Repo, cmt.CommentID), map[string]string{
+			"body": body,
+		}, nil); err != nil {
+			return fmt.Errorf("edit a comment by Gitea API: %w", err)
+		}
+		return nil
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPost, repoPath(cmt.Org, cmt.Repo)+"/issues/"+strconv.Itoa(cmt.PRNumber)+"/comments", map[string]string{
+		"body": body,
+	}, nil); err != nil {
+		return fmt.Errorf("create a comment by Gitea API: %w", err)
+	}
+	return nil
+}
+
+// ListComments lists comments of the pull request.
+// The ID of each comment is the API path of the comment.
+// Comments hidden by github-comment are treated as minimized comments.
+// The API doesn't support pagination and returns all comments at once, so the list is fetched only once.
+func (c *Client) ListComments(ctx context.Context, pr *github.PullRequest) ([]*github.IssueComment, error) {
+	var cmts []*comment
+	if _, err := c.rest.Do(ctx, http.MethodGet, fmt.Sprintf("%s/issues/%d/comments", repoPath(pr.Org, pr.Repo), pr.PRNumber), nil, &cmts); err != nil {
+		return nil, fmt.Errorf("list comments by Gitea API: %w", err)
+	}
+	comments := make([]*github.IssueComment, 0, len(cmts))
+	for _, cmt := range cmts {
+		ic := &github.IssueComment{
+			ID:                commentPath(pr.Org, pr.Repo, cmt.ID),
+			DatabaseID:        cmt.ID,
+			Kind:              github.CommentKindIssueComment,
+			Body:              cmt.Body,
+			CreatedAt:         cmt.CreatedAt,
+			URL:               cmt.HTMLURL,
+			IsMinimized:       isOutdated(cmt.Body),
+			MinimizedReason:   minimizedReason(cmt.Body),
+			ViewerCanMinimize: true,
+			ViewerCanDelete:   true,
+		}
+		ic.Author.Login = cmt.User.Login
+		comments = append(comments, ic)
+	}
+	return comments, nil
+}
+
+func isOutdated(body string) bool {
+	return strings.HasPrefix(body, outdatedMarker)
+}
+
+func minimizedReason(body string) string {
+	if isOutdated(body) {
+		return "outdated"
+	}
+	return ""
+}
+
+const (
+	outdatedPrefix = outdatedMarker + "\n<details>\n<summary>This comment is outdated</summary>\n\n"
+	outdatedSuffix = "\n\n</details>"
+)
+
+// wrapOutdated wraps the comment body in <details> with the marker.
+func wrapOutdated(body string) string {
+	return outdatedPrefix + body + outdatedSuffix
+}
+
+// unwrapOutdated restores the comment body wrapped by wrapOutdated.
+func unwrapOutdated(body string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(body, outdatedPrefix), outdatedSuffix)
+}
+
+// HideComment hides the comment by wrapping the body in <details>.
+// nodeID is the API path of the comment returned by ListComments.
+// Gitea doesn't have classifiers, so classifier is ignored.
+func (c *Client) HideComment(ctx context.Context, nodeID, classifier string) error {
+	cmt := &comment{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, nodeID, nil, cmt); err != nil {
+		return fmt.Errorf("get a comment by Gitea API: %w", err)
+	}
+	if isOutdated(cmt.Body) {
+		return nil
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPatch, nodeID, map[string]string{
+		"body": wrapOutdated(cmt.Body),
+	}, nil); err != nil {
+		return fmt.Errorf("edit a comment to hide it by Gitea API: %w", err)
+	}
+	return nil
+}
+
+// UnhideComment restores the comment hidden by HideComment.
+func (c *Client) UnhideComment(ctx context.Context, nodeID string) error {
+	cmt := &comment{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, nodeID, nil, cmt); err != nil {
+		return fmt.Errorf("get a comment by Gitea API: %w", err)
+	}
+	if !isOutdated(cmt.Body) {
+		return nil
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPatch, nodeID, map[string]string{
+		"body": unwrapOutdated(cmt.Body),
+	}, nil); err != nil {
+		return fmt.Errorf("edit a comment to unhide it by Gitea API: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteComment(ctx context.Context, pr *github.PullRequest, commentID int64) error {
+	if _, err := c.rest.Do(ctx, http.MethodDelete, commentPath(pr.Org, pr.Repo, commentID), nil, nil); err != nil {
+		return fmt.Errorf("delete a comment by Gitea API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/rest"
+)
+
+// PRNumberWithSHA returns the number of the pull request associated with the commit.
+// If no pull request is found, 0 is returned.
+func (c *Client) PRNumberWithSHA(ctx context.Context, owner, repo, sha string) (int, error) {
+	pr := struct {
+		Number int `json:"number"`
+	}{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, repoPath(owner, repo)+"/commits/"+sha+"/pull", nil, &pr); err != nil {
+		if rest.IsStatus(err, http.StatusNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get a pull request associated with a commit by Gitea API: %w", err)
+	}
+	return pr.Number, nil
+}
+
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (string, error) {
+	user := struct {
+		Login string `json:"login"`
+	}{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, "user", nil, &user); err != nil {
+		return "", fmt.Errorf("get an authenticated user by Gitea API: %w", err)
+	}
+	return user.Login, nil
+}
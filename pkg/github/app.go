@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-github/v90/github"
+	"golang.org/x/oauth2"
+)
+
+// App is a GitHub App to authenticate as.
+type App struct {
+	AppID int64
+	// PrivateKey is the PEM encoded private key of the GitHub App.
+	PrivateKey []byte
+	// InstallationID is optional.
+	// If InstallationID is 0, the installation is found from the repository returned by Repo.
+	InstallationID int64
+	// Repo returns the repository owner and name.
+	// Repo is called when an installation access token is created first,
+	// so the repository can be complemented after the client is created.
+	Repo func() (string, string)
+}
+
+type AppsService interface {
+	Get(ctx context.Context, appSlug string) (*github.App, *github.Response, error)
+	GetRepositoryInstallation(ctx context.Context, owner, repo string) (*github.Installation, *github.Response, error)
+	CreateInstallationToken(ctx context.Context, id int64, opts *github.InstallationTokenOptions) (*github.InstallationToken, *github.Response, error)
+}
+
+const (
+	// jwtExpiration is the expiration of JWTs. GitHub allows up to 10 minutes.
+	jwtExpiration = 9 * time.Minute
+	// tokenExpiryDelta is the time to refresh an installation access token before it expires.
+	tokenExpiryDelta = 5 * time.Minute
+)
+
+func parsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse the private key of the GitHub App: %w", err)
+	}
+	return key, nil
+}
+
+// jwtSource creates JWTs to authenticate as the GitHub App.
+type jwtSource struct {
+	appID int64
+	key   *rsa.PrivateKey
+	now   func() time.Time
+}
+
+func (s *jwtSource) Token() (*oauth2.Token, error) {
+	// iat is set 60 seconds in the past to allow for clock drift.
+	now := s.now()
+	exp := now.Add(jwtExpiration)
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(exp),
+		Issuer:    strconv.FormatInt(s.appID, 10),
+	}).SignedString(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("sign a JWT: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: signed,
+		TokenType:   "Bearer",
+		Expiry:      exp,
+	}, nil
+}
+
+// installationTokenSource creates installation access tokens of the GitHub App.
+type installationTokenSource struct {
+	ctx            context.Context //nolint:containedctx
+	apps           AppsService
+	installationID int64
+	repo           func() (string, string)
+	mutex          sync.Mutex
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	id, err := s.getInstallationID()
+	if err != nil {
+		return nil, err
+	}
+	token, _, err := s.apps.CreateInstallationToken(s.ctx, id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create an installation access token of the GitHub App: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "Bearer",
+		Expiry:      token.GetExpiresAt().Time,
+	}, nil
+}
+
+func (s *installationTokenSource) getInstallationID() (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.installationID != 0 {
+		return s.installationID, nil
+	}
+	if s.repo == nil {
+		return 0, errors.New("the installation id of the GitHub App is required")
+	}
+	owner, repo := s.repo()
+	if owner == "" || repo == "" {
+		return 0, errors.New("the repository is required to find the installation of the GitHub App")
+	}
+	installation, _, err := s.apps.GetRepositoryInstallation(s.ctx, owner, repo)
+	if err != nil {
+		return 0, fmt.Errorf("get a GitHub App installation for the repository: %w", err)
+	}
+	s.installationID = installation.GetID()
+	return s.installationID, nil
+}
+
+// newAppTokenSource returns a token source of installation access tokens and GitHub Apps API client authenticated as the GitHub App.
+// Installation access tokens are created when they're needed and refreshed before they expire.
+func newAppTokenSource(ctx context.Context, app *App, opts []github.ClientOptionsFunc, retry *Retry, logger *slog.Logger) (oauth2.TokenSource, AppsService, error) {
+	key, err := parsePrivateKey(app.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	jwtClient := WithRetry(oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, &jwtSource{
+		appID: app.AppID,
+		key:   key,
+		now:   time.Now,
+	})), retry, logger)
+	gh, err := github.NewClient(append([]github.ClientOptionsFunc{github.WithHTTPClient(jwtClient)}, opts...)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initialize GitHub API Client for the GitHub App: %w", err)
+	}
+	return oauth2.ReuseTokenSourceWithExpiry(nil, &installationTokenSource{
+		ctx:            ctx,
+		apps:           gh.Apps,
+		installationID: app.InstallationID,
+		repo:           app.Repo,
+	}, tokenExpiryDelta), gh.Apps, nil
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-github/v90/github"
+	"github.com/stretchr/testify/require"
+)
+
+type mockApps struct {
+	installationRepo string
+}
+
+func (m *mockApps) Get(_ context.Context, _ string) (*github.App, *github.Response, error) {
+	return &github.App{Slug: new("my-app")}, nil, nil
+}
+
+func (m *mockApps) GetRepositoryInstallation(_ context.Context, owner, repo string) (*github.Installation, *github.Response, error) {
+	m.installationRepo = owner + "/" + repo
+	return &github.Installation{ID: new(int64(10))}, nil, nil
+}
+
+func (m *mockApps) CreateInstallationToken(_ context.Context, id int64, _ *github.InstallationTokenOptions) (*github.InstallationToken, *github.Response, error) {
+	if id != 10 {
+		return nil, nil, errors.New("installation isn't found")
+	}
+	return &github.InstallationToken{
+		Token:     new("ghs_xxx"),
+		ExpiresAt: &github.Timestamp{Time: time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)},
+	}, nil, nil
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	for _, b := range []*pem.Block{
+		{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		k, err := parsePrivateKey(pem.EncodeToMemory(b))
+		require.NoError(t, err)
+		require.True(t, key.Equal(k))
+	}
+	_, err = parsePrivateKey([]byte("foo"))
+	require.Error(t, err)
+}
+
+func TestJWTSource_Token(t *testing.T) {
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	token, err := (&jwtSource{
+		appID: 123,
+		key:   key,
+		now:   func() time.Time { return now },
+	}).Token()
+	require.NoError(t, err)
+	require.Equal(t, now.Add(jwtExpiration), token.Expiry)
+
+	claims := &jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(token.AccessToken, claims, func(*jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithTimeFunc(func() time.Time { return now }))
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-time.Minute).Unix(), claims.IssuedAt.Unix())
+	require.Equal(t, now.Add(jwtExpiration).Unix(), claims.ExpiresAt.Unix())
+	require.Equal(t, "123", claims.Issuer)
+}
+
+func TestInstallationTokenSource_Token(t *testing.T) {
+	t.Parallel()
+	apps := &mockApps{}
+	ts := &installationTokenSource{
+		ctx:  context.Background(),
+		apps: apps,
+		repo: func() (string, string) {
+			return "suzuki-shunsuke", "github-comment"
+		},
+	}
+	token, err := ts.Token()
+	require.NoError(t, err)
+	require.Equal(t, "ghs_xxx", token.AccessToken)
+	require.Equal(t, time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC), token.Expiry)
+	require.Equal(t, "suzuki-shunsuke/github-comment", apps.installationRepo)
+	require.Equal(t, int64(10), ts.installationID)
+}
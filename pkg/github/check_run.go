@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v90/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/parser"
+)
+
+// CheckRun is a completed GitHub Check Run.
+type CheckRun struct {
+	Org  string
+	Repo string
+	SHA1 string
+	Name string
+	// Conclusion is either success or failure
+	Conclusion string
+	Title      string
+	Summary    string
+	Text       string
+	// Annotations are attached to the check run.
+	Annotations []*parser.Annotation
+}
+
+// maxAnnotationsPerRequest is the maximum number of annotations per request.
+// https://docs.github.com/en/rest/checks/runs#update-a-check-run
+const maxAnnotationsPerRequest = 50
+
+// CreateCheckRun creates a check run.
+// A new check run is created every time even if the check run with the same name already exists in the commit,
+// because GitHub appends annotations to the existing check run instead of replacing them.
+// GitHub shows the latest check run with the same name.
+func (c *Client) CreateCheckRun(ctx context.Context, checkRun *CheckRun) error {
+	annotations := convertAnnotations(checkRun.Annotations)
+	now := github.Timestamp{Time: c.now()}
+	run, _, err := c.checks.CreateCheckRun(ctx, checkRun.Org, checkRun.Repo, github.CreateCheckRunOptions{
+		Name:        checkRun.Name,
+		HeadSHA:     checkRun.SHA1,
+		Status:      new("completed"),
+		Conclusion:  new(checkRun.Conclusion),
+		CompletedAt: &now,
+		Output:      checkRunOutput(checkRun, annotations[:min(len(annotations), maxAnnotationsPerRequest)]),
+	})
+	if err != nil {
+		return fmt.Errorf("create a check run by GitHub API: %w", err)
+	}
+	// The number of annotations per request is limited, so the rest of annotations are added by updating the check run.
+	for i := maxAnnotationsPerRequest; i < len(annotations); i += maxAnnotationsPerRequest {
+		if _, _, err := c.checks.UpdateCheckRun(ctx, checkRun.Org, checkRun.Repo, run.GetID(), github.UpdateCheckRunOptions{
+			Name:   checkRun.Name,
+			Output: checkRunOutput(checkRun, annotations[i:min(len(annotations), i+maxAnnotationsPerRequest)]),
+		}); err != nil {
+			return fmt.Errorf("add annotations to a check run by GitHub API: %w", err)
+		}
+	}
+	return nil
+}
+
+func checkRunOutput(checkRun *CheckRun, annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	return &github.CheckRunOutput{
+		Title:       new(checkRun.Title),
+		Summary:     new(checkRun.Summary),
+		Text:        new(checkRun.Text),
+		Annotations: annotations,
+	}
+}
+
+func convertAnnotations(annotations []*parser.Annotation) []*github.CheckRunAnnotation {
+	ret := make([]*github.CheckRunAnnotation, len(annotations))
+	for i, a := range annotations {
+		annotation := &github.CheckRunAnnotation{
+			Path:            new(a.Path),
+			StartLine:       new(a.StartLine),
+			EndLine:         new(max(a.EndLine, a.StartLine)),
+			AnnotationLevel: new(a.Level),
+			Message:         new(a.Message),
+		}
+		if a.Title != "" {
+			annotation.Title = new(a.Title)
+		}
+		// GitHub rejects columns of annotations over multiple lines
+		if a.StartColumn > 0 && a.StartLine == *annotation.EndLine {
+			annotation.StartColumn = new(a.StartColumn)
+			annotation.EndColumn = new(max(a.EndColumn, a.StartColumn))
+		}
+		ret[i] = annotation
+	}
+	return ret
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/parser"
+)
+
+type mockChecks struct {
+	created []github.CreateCheckRunOptions
+	updated []github.UpdateCheckRunOptions
+}
+
+func (m *mockChecks) CreateCheckRun(_ context.Context, _, _ string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	m.created = append(m.created, opts)
+	return &github.CheckRun{ID: new(int64(len(m.created)))}, nil, nil
+}
+
+func (m *mockChecks) UpdateCheckRun(_ context.Context, _, _ string, _ int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	m.updated = append(m.updated, opts)
+	return &github.CheckRun{}, nil, nil
+}
+
+func TestClient_CreateCheckRun(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title          string
+		numAnnotations int
+		expUpdated     []int
+	}{
+		{
+			title: "no annotation",
+		},
+		{
+			title:          "annotations are sent by one request",
+			numAnnotations: 50,
+		},
+		{
+			title:          "the rest of annotations are added by updating the check run",
+			numAnnotations: 120,
+			expUpdated:     []int{50, 20},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			checks := &mockChecks{}
+			client := &Client{
+				checks: checks,
+				now: func() time.Time {
+					return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+				},
+			}
+			annotations := make([]*parser.Annotation, d.numAnnotations)
+			for i := range annotations {
+				annotations[i] = &parser.Annotation{Path: "main.go", StartLine: i + 1, Level: parser.LevelFailure, Message: "foo"}
+			}
+			checkRun := &CheckRun{
+				Org:         "suzuki-shunsuke",
+				Repo:        "github-comment",
+				SHA1:        "abc",
+				Name:        "test",
+				Conclusion:  "failure",
+				Annotations: annotations,
+			}
+			// running twice creates two check runs instead of adding annotations to the existing one
+			for range 2 {
+				require.NoError(t, client.CreateCheckRun(context.Background(), checkRun))
+			}
+			require.Len(t, checks.created, 2)
+			require.Len(t, checks.created[0].Output.Annotations, min(d.numAnnotations, maxAnnotationsPerRequest))
+			require.Equal(t, "abc", checks.created[0].HeadSHA)
+			require.Equal(t, "failure", checks.created[0].GetConclusion())
+			updated := make([]int, 0, len(checks.updated))
+			for _, u := range checks.updated[:len(checks.updated)/2] {
+				updated = append(updated, len(u.Output.Annotations))
+			}
+			if d.expUpdated == nil {
+				require.Empty(t, updated)
+				return
+			}
+			require.Equal(t, d.expUpdated, updated)
+		})
+	}
+}
+
+func Test_convertAnnotations(t *testing.T) {
+	t.Parallel()
+	annotations := convertAnnotations([]*parser.Annotation{
+		{Path: "main.go", StartLine: 3, EndLine: 3, StartColumn: 2, EndColumn: 4, Level: parser.LevelFailure, Message: "foo"},
+		{Path: "main.go", StartLine: 3, EndLine: 5, StartColumn: 2, EndColumn: 4, Level: parser.LevelWarning, Message: "bar", Title: "title"},
+	})
+	require.Equal(t, []*github.CheckRunAnnotation{
+		{
+			Path:            new("main.go"),
+			StartLine:       new(3),
+			EndLine:         new(3),
+			StartColumn:     new(2),
+			EndColumn:       new(4),
+			AnnotationLevel: new(parser.LevelFailure),
+			Message:         new("foo"),
+		},
+		{
+			// columns are dropped because GitHub rejects columns of annotations over multiple lines
+			Path:            new("main.go"),
+			StartLine:       new(3),
+			EndLine:         new(5),
+			AnnotationLevel: new(parser.LevelWarning),
+			Message:         new("bar"),
+			Title:           new("title"),
+		},
+	}, annotations)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/go-github/v90/github"
 	"github.com/shurcooL/githubv4"
@@ -15,29 +16,48 @@ type Client struct {
 	pr     PullRequestsService
 	repo   RepositoriesService
 	user   UsersService
+	checks ChecksService
+	gist   GistsService
+	// apps is set if the client is authenticated as a GitHub App
+	apps   AppsService
 	ghV4   V4Client
 	logger *slog.Logger
+	now    func() time.Time
 }
 
 type ParamNew struct {
 	Token              string
 	GHEBaseURL         string
 	GHEGraphQLEndpoint string
-	Logger             *slog.Logger
+	// App is set to authenticate as a GitHub App instead of Token
+	App *App
+	// Retry is the configuration to retry requests. If Retry is nil, requests aren't retried
+	Retry  *Retry
+	Logger *slog.Logger
 }
 
 func New(ctx context.Context, param *ParamNew) (*Client, error) {
-	httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: param.Token},
-	))
 	client := &Client{
 		logger: param.Logger,
+		now:    time.Now,
 	}
-	opts := []github.ClientOptionsFunc{github.WithHTTPClient(httpClient)}
+	var opts []github.ClientOptionsFunc
 	if param.GHEBaseURL != "" {
 		opts = append(opts, github.WithEnterpriseURLs(param.GHEBaseURL, param.GHEBaseURL))
 	}
-	gh, err := github.NewClient(opts...)
+	var tokenSource oauth2.TokenSource = oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: param.Token},
+	)
+	if param.App != nil {
+		ts, apps, err := newAppTokenSource(ctx, param.App, opts, param.Retry, param.Logger)
+		if err != nil {
+			return nil, err
+		}
+		tokenSource = ts
+		client.apps = apps
+	}
+	httpClient := WithRetry(oauth2.NewClient(ctx, tokenSource), param.Retry, param.Logger)
+	gh, err := github.NewClient(append([]github.ClientOptionsFunc{github.WithHTTPClient(httpClient)}, opts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("initialize GitHub API Client: %w", err)
 	}
@@ -45,6 +65,8 @@ func New(ctx context.Context, param *ParamNew) (*Client, error) {
 	client.repo = gh.Repositories
 	client.user = gh.Users
 	client.pr = gh.PullRequests
+	client.checks = gh.Checks
+	client.gist = gh.Gists
 	if param.GHEGraphQLEndpoint == "" {
 		client.ghV4 = githubv4.NewClient(httpClient)
 	} else {
@@ -62,6 +84,7 @@ type V4Client interface {
 type IssuesService interface {
 	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
 	EditComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	DeleteComment(ctx context.Context, owner string, repo string, commentID int64) (*github.Response, error)
 }
 
 type RepositoriesService interface {
@@ -75,4 +98,16 @@ type UsersService interface {
 
 type PullRequestsService interface {
 	ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) ([]*github.PullRequest, *github.Response, error)
+	Get(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.PullRequestComment) (*github.PullRequestComment, *github.Response, error)
+	CreateReview(ctx context.Context, owner string, repo string, number int, review *github.PullRequestReviewRequest) (*github.PullRequestReview, *github.Response, error)
+}
+
+type ChecksService interface {
+	CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+}
+
+type GistsService interface {
+	Create(ctx context.Context, gist github.CreateGistRequest) (*github.Gist, *github.Response, error)
 }
@@ -2,9 +2,13 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/go-github/v90/github"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
 type Comment struct {
@@ -17,19 +21,47 @@ type Comment struct {
 	SHA1           string
 	TemplateKey    string
 	Vars           map[string]any
+	// Path, Line, and Side are the location of the review comment.
+	// If Path is empty, the comment is posted to the pull request conversation.
+	Path string
+	Line int
+	Side string
+	// Parts are bodies of comments split from a too long body.
+	// If Parts isn't empty, each part is posted as a comment instead of Body.
+	Parts []string
+	// GroupCommentIDs are ids of existing comments split from a too long body.
+	// They are updated with Parts in order, and leftover comments are deleted.
+	GroupCommentIDs []int64
 }
 
+// MaxCommentLength is the maximum length of a comment body.
+const MaxCommentLength = 65536
+
+// Kinds of comments. They are GraphQL type names.
+const (
+	CommentKindIssueComment             = "IssueComment"
+	CommentKindPullRequestReview        = "PullRequestReview"
+	CommentKindPullRequestReviewComment = "PullRequestReviewComment"
+)
+
 type IssueComment struct {
 	ID         string
 	DatabaseID int64
-	Body       string
-	Author     struct {
+	// Kind is the kind of the comment such as CommentKindIssueComment
+	Kind   string `graphql:"__typename"`
+	Body   string
+	Author struct {
 		Login string
 	}
-	CreatedAt string
+	CreatedAt time.Time
+	// URL is the URL of the comment. It may be empty if the Git hosting service doesn't provide it
+	URL string
 	// TODO remove
-	IsMinimized       bool
+	IsMinimized bool
+	// MinimizedReason is the reason why the comment was hidden such as "outdated"
+	MinimizedReason   string
 	ViewerCanMinimize bool
+	ViewerCanDelete   bool
 }
 
 func (c *Client) sendIssueComment(ctx context.Context, cmt *Comment, body string) error {
@@ -66,6 +98,40 @@ func (c *Client) sendCommitComment(ctx context.Context, cmt *Comment, body strin
 	return nil
 }
 
+func (c *Client) sendReviewComment(ctx context.Context, cmt *Comment, body string) error {
+	commitID := cmt.SHA1
+	if commitID == "" {
+		pr, _, err := c.pr.Get(ctx, cmt.Org, cmt.Repo, cmt.PRNumber)
+		if err != nil {
+			return fmt.Errorf("get a pull request by GitHub API: %w", err)
+		}
+		commitID = pr.GetHead().GetSHA()
+	}
+	prCmt := &github.PullRequestComment{
+		Body:     new(body),
+		CommitID: new(commitID),
+		Path:     new(cmt.Path),
+		Line:     new(cmt.Line),
+	}
+	if cmt.Side != "" {
+		prCmt.Side = new(cmt.Side)
+	}
+	if _, _, err := c.pr.CreateComment(ctx, cmt.Org, cmt.Repo, cmt.PRNumber, prCmt); err != nil {
+		return fmt.Errorf("create a review comment by GitHub API: %w", err)
+	}
+	return nil
+}
+
+// isUnprocessable returns true if GitHub API returns 422 Unprocessable Entity.
+// GitHub API returns 422 if the line of the review comment isn't in the diff.
+func isUnprocessable(err error) bool {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	return errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnprocessableEntity
+}
+
 func (c *Client) createComment(ctx context.Context, cmt *Comment, tooLong bool) error {
 	body := cmt.Body
 	if tooLong {
@@ -74,6 +140,16 @@ func (c *Client) createComment(ctx context.Context, cmt *Comment, tooLong bool)
 		)
 		body = cmt.BodyForTooLong
 	}
+	if cmt.PRNumber != 0 && cmt.Path != "" && cmt.CommentID == 0 {
+		err := c.sendReviewComment(ctx, cmt, body)
+		if err == nil || !isUnprocessable(err) {
+			return err
+		}
+		slogerr.WithError(c.logger, err).Warn("post the comment to the pull request conversation as the review comment can't be posted",
+			"path", cmt.Path,
+			"line", cmt.Line,
+		)
+	}
 	if cmt.PRNumber != 0 {
 		return c.sendIssueComment(ctx, cmt, body)
 	}
@@ -81,5 +157,5 @@ func (c *Client) createComment(ctx context.Context, cmt *Comment, tooLong bool)
 }
 
 func (c *Client) CreateComment(ctx context.Context, cmt *Comment) error {
-	return c.createComment(ctx, cmt, len(cmt.Body) > 65536) //nolint:mnd
+	return c.createComment(ctx, cmt, len(cmt.Body) > MaxCommentLength)
 }
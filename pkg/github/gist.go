@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// Gist is a secret gist which has a single file.
+type Gist struct {
+	Description string
+	FileName    string
+	Content     string
+}
+
+// CreateGist creates a secret gist and returns the URL.
+// In GitHub Enterprise Server, the gist is created in the GitHub Enterprise Server.
+func (c *Client) CreateGist(ctx context.Context, gist *Gist) (string, error) {
+	g, _, err := c.gist.Create(ctx, github.CreateGistRequest{
+		Description: new(gist.Description),
+		Public:      new(false),
+		Files: map[github.GistFilename]*github.CreateGistFile{
+			github.GistFilename(gist.FileName): {
+				Content: gist.Content,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create a gist by GitHub API: %w", err)
+	}
+	return g.GetHTMLURL(), nil
+}
@@ -11,6 +11,8 @@ type PullRequest struct {
 	PRNumber int
 	Org      string
 	Repo     string
+	// IncludeReviews is true if pull request reviews and review comments are listed in addition to issue comments
+	IncludeReviews bool
 }
 
 func (c *Client) listIssueComment(ctx context.Context, pr *PullRequest) ([]*IssueComment, error) { //nolint:dupl
@@ -85,10 +87,101 @@ func (c *Client) listPRComment(ctx context.Context, pr *PullRequest) ([]*IssueCo
 	return allComments, nil
 }
 
+func (c *Client) listPRReview(ctx context.Context, pr *PullRequest) ([]*IssueComment, error) { //nolint:dupl
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				Reviews struct {
+					Nodes    []*IssueComment
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+				} `graphql:"reviews(first: 100, after: $reviewsCursor)"` // 100 per page.
+			} `graphql:"pullRequest(number: $issueNumber)"`
+		} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+	}
+	variables := map[string]any{
+		"repositoryOwner": githubv4.String(pr.Org),
+		"repositoryName":  githubv4.String(pr.Repo),
+		"issueNumber":     githubv4.Int(pr.PRNumber), //nolint:gosec // PR number won't overflow int32
+		"reviewsCursor":   (*githubv4.String)(nil),   // Null after argument to get first page.
+	}
+
+	var allReviews []*IssueComment
+	for {
+		if err := c.ghV4.Query(ctx, &q, variables); err != nil {
+			return nil, fmt.Errorf("list pull request reviews by GitHub API: %w", err)
+		}
+		allReviews = append(allReviews, q.Repository.PullRequest.Reviews.Nodes...)
+		if !q.Repository.PullRequest.Reviews.PageInfo.HasNextPage {
+			break
+		}
+		variables["reviewsCursor"] = new(q.Repository.PullRequest.Reviews.PageInfo.EndCursor)
+	}
+	return allReviews, nil
+}
+
+// listPRReviewComment lists comments of review threads.
+// Only the first 100 comments of each thread are listed.
+func (c *Client) listPRReviewComment(ctx context.Context, pr *PullRequest) ([]*IssueComment, error) {
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						Comments struct {
+							Nodes []*IssueComment
+						} `graphql:"comments(first: 100)"`
+					}
+					PageInfo struct {
+						EndCursor   githubv4.String
+						HasNextPage bool
+					}
+				} `graphql:"reviewThreads(first: 100, after: $threadsCursor)"` // 100 per page.
+			} `graphql:"pullRequest(number: $issueNumber)"`
+		} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+	}
+	variables := map[string]any{
+		"repositoryOwner": githubv4.String(pr.Org),
+		"repositoryName":  githubv4.String(pr.Repo),
+		"issueNumber":     githubv4.Int(pr.PRNumber), //nolint:gosec // PR number won't overflow int32
+		"threadsCursor":   (*githubv4.String)(nil),   // Null after argument to get first page.
+	}
+
+	var allComments []*IssueComment
+	for {
+		if err := c.ghV4.Query(ctx, &q, variables); err != nil {
+			return nil, fmt.Errorf("list pull request review threads by GitHub API: %w", err)
+		}
+		for _, thread := range q.Repository.PullRequest.ReviewThreads.Nodes {
+			allComments = append(allComments, thread.Comments.Nodes...)
+		}
+		if !q.Repository.PullRequest.ReviewThreads.PageInfo.HasNextPage {
+			break
+		}
+		variables["threadsCursor"] = new(q.Repository.PullRequest.ReviewThreads.PageInfo.EndCursor)
+	}
+	return allComments, nil
+}
+
+// ListComments lists comments of the pull request or the issue.
+// If pr.IncludeReviews is true, pull request reviews and review comments are also listed after issue comments.
 func (c *Client) ListComments(ctx context.Context, pr *PullRequest) ([]*IssueComment, error) {
 	cmts, prErr := c.listPRComment(ctx, pr)
 	if prErr == nil {
-		return cmts, nil
+		if !pr.IncludeReviews {
+			return cmts, nil
+		}
+		reviews, err := c.listPRReview(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		reviewComments, err := c.listPRReviewComment(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		return append(append(cmts, reviews...), reviewComments...), nil
 	}
 	cmts, err := c.listIssueComment(ctx, pr)
 	if err == nil {
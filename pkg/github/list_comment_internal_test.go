@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListComments(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Query string `json:"query"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case strings.Contains(body.Query, "reviewThreads("):
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviewThreads": {
+  "nodes": [{"comments": {"nodes": [{"__typename": "PullRequestReviewComment", "id": "c", "databaseId": 3, "body": "thread"}]}}],
+  "pageInfo": {"hasNextPage": false}
+}}}}}`))
+		case strings.Contains(body.Query, "reviews("):
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviews": {
+  "nodes": [{"__typename": "PullRequestReview", "id": "b", "databaseId": 2, "body": "review"}],
+  "pageInfo": {"hasNextPage": false}
+}}}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"comments": {
+  "nodes": [{"__typename": "IssueComment", "id": "a", "databaseId": 1, "body": "comment"}],
+  "pageInfo": {"hasNextPage": false}
+}}}}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := &Client{
+		ghV4: githubv4.NewEnterpriseClient(srv.URL, srv.Client()),
+	}
+	pr := &PullRequest{Org: "suzuki-shunsuke", Repo: "github-comment", PRNumber: 1}
+
+	comments, err := client.ListComments(context.Background(), pr)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, CommentKindIssueComment, comments[0].Kind)
+
+	pr.IncludeReviews = true
+	comments, err = client.ListComments(context.Background(), pr)
+	require.NoError(t, err)
+	kinds := make([]string, len(comments))
+	for i, cmt := range comments {
+		kinds[i] = cmt.Kind
+	}
+	require.Equal(t, []string{
+		CommentKindIssueComment,
+		CommentKindPullRequestReview,
+		CommentKindPullRequestReviewComment,
+	}, kinds)
+}
@@ -22,11 +22,59 @@ func (m *Mock) CreateComment(ctx context.Context, cmt *Comment) error {
 	if cmt.PRNumber != 0 {
 		msg += " issue:" + strconv.Itoa(cmt.PRNumber)
 	}
+	if cmt.Path != "" {
+		msg += " path:" + cmt.Path + " line:" + strconv.Itoa(cmt.Line)
+	}
 	fmt.Fprintln(m.Stderr, msg+"\n[github-comment][DRYRUN] "+cmt.Body)
 	return nil
 }
 
-func (m *Mock) HideComment(ctx context.Context, nodeID string) error {
+func (m *Mock) CreateReview(ctx context.Context, review *Review) error {
+	if m.Silent {
+		return nil
+	}
+	msg := "[github-comment][DRYRUN] Review to " + review.Org + "/" + review.Repo + " pr:" + strconv.Itoa(review.PRNumber) + " event:" + review.Event
+	msg += "\n[github-comment][DRYRUN] " + review.Body
+	for _, cmt := range review.Comments {
+		msg += "\n[github-comment][DRYRUN] path:" + cmt.Path + " line:" + strconv.Itoa(cmt.Line) + "\n[github-comment][DRYRUN] " + cmt.Body
+	}
+	fmt.Fprintln(m.Stderr, msg)
+	return nil
+}
+
+func (m *Mock) CreateCheckRun(ctx context.Context, checkRun *CheckRun) error {
+	if m.Silent {
+		return nil
+	}
+	fmt.Fprintln(m.Stderr, "[github-comment][DRYRUN] Check Run to "+checkRun.Org+"/"+checkRun.Repo+" sha1:"+checkRun.SHA1+" name:"+checkRun.Name+" conclusion:"+checkRun.Conclusion+
+		"\n[github-comment][DRYRUN] "+checkRun.Summary)
+	return nil
+}
+
+func (m *Mock) CreateGist(ctx context.Context, gist *Gist) (string, error) {
+	if !m.Silent {
+		fmt.Fprintln(m.Stderr, "[github-comment][DRYRUN] Create a secret gist "+gist.FileName+" ("+strconv.Itoa(len(gist.Content))+" bytes)")
+	}
+	return "https://gist.github.com/dry-run", nil
+}
+
+func (m *Mock) HideComment(ctx context.Context, nodeID, classifier string) error {
+	return nil
+}
+
+func (m *Mock) UnhideComment(ctx context.Context, nodeID string) error {
+	if m.Silent {
+		return nil
+	}
+	fmt.Fprintln(m.Stderr, "[github-comment][DRYRUN] Unhide a comment "+nodeID)
+	return nil
+}
+
+func (m *Mock) DeleteComment(ctx context.Context, pr *PullRequest, commentID int64) error {
+	if m.Silent {
+		return nil
+	}
+	fmt.Fprintln(m.Stderr, "[github-comment][DRYRUN] Delete a comment "+pr.Org+"/"+pr.Repo+" issue:"+strconv.Itoa(pr.PRNumber)+" comment_id:"+strconv.FormatInt(commentID, 10))
 	return nil
 }
 
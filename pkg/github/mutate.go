@@ -2,12 +2,57 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/shurcooL/githubv4"
 )
 
-func (c *Client) HideComment(ctx context.Context, nodeID string) error {
+// Classifiers are reasons why comments are minimized.
+// https://docs.github.com/en/graphql/reference/enums#reportedcontentclassifiers
+const (
+	ClassifierAbuse     = "ABUSE"
+	ClassifierDuplicate = "DUPLICATE"
+	ClassifierOffTopic  = "OFF_TOPIC"
+	ClassifierOutdated  = "OUTDATED"
+	ClassifierResolved  = "RESOLVED"
+	ClassifierSpam      = "SPAM"
+)
+
+var classifiers = []string{
+	ClassifierAbuse,
+	ClassifierDuplicate,
+	ClassifierOffTopic,
+	ClassifierOutdated,
+	ClassifierResolved,
+	ClassifierSpam,
+}
+
+// IsClassifier returns true if s is a classifier name.
+// The comparison is case insensitive.
+func IsClassifier(s string) bool {
+	return slices.Contains(classifiers, strings.ToUpper(s))
+}
+
+// ValidateClassifier returns an error if classifier isn't empty and isn't a classifier name.
+func ValidateClassifier(classifier string) error {
+	if classifier == "" || IsClassifier(classifier) {
+		return nil
+	}
+	return errors.New("invalid classifier: " + classifier + ". classifier must be one of " + strings.Join(classifiers, ", "))
+}
+
+// HideComment minimizes the comment.
+// If classifier is empty, the comment is minimized as OUTDATED.
+func (c *Client) HideComment(ctx context.Context, nodeID, classifier string) error {
+	if err := ValidateClassifier(classifier); err != nil {
+		return err
+	}
+	if classifier == "" {
+		classifier = ClassifierOutdated
+	}
 	var m struct {
 		MinimizeComment struct {
 			MinimizedComment struct {
@@ -18,7 +63,7 @@ func (c *Client) HideComment(ctx context.Context, nodeID string) error {
 		} `graphql:"minimizeComment(input:$input)"`
 	}
 	input := githubv4.MinimizeCommentInput{
-		Classifier: githubv4.ReportedContentClassifiersOutdated,
+		Classifier: githubv4.ReportedContentClassifiers(strings.ToUpper(classifier)),
 		SubjectID:  nodeID,
 	}
 	if err := c.ghV4.Mutate(ctx, &m, input, nil); err != nil {
@@ -26,3 +71,28 @@ func (c *Client) HideComment(ctx context.Context, nodeID string) error {
 	}
 	return nil
 }
+
+func (c *Client) UnhideComment(ctx context.Context, nodeID string) error {
+	var m struct {
+		UnminimizeComment struct {
+			UnminimizedComment struct {
+				IsMinimized       githubv4.Boolean
+				ViewerCanMinimize githubv4.Boolean
+			}
+		} `graphql:"unminimizeComment(input:$input)"`
+	}
+	input := githubv4.UnminimizeCommentInput{
+		SubjectID: nodeID,
+	}
+	if err := c.ghV4.Mutate(ctx, &m, input, nil); err != nil {
+		return fmt.Errorf("unhide a comment: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteComment(ctx context.Context, pr *PullRequest, commentID int64) error {
+	if _, err := c.issue.DeleteComment(ctx, pr.Org, pr.Repo, commentID); err != nil {
+		return fmt.Errorf("delete an issue or pull request comment by GitHub API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retry is the configuration to retry GitHub API requests.
+type Retry struct {
+	// MaxRetries is the maximum number of retries. If MaxRetries is 0, requests aren't retried.
+	MaxRetries int
+	// MaxWait is the maximum wait time before retrying a request.
+	// If GitHub API requires to wait longer than MaxWait, the request isn't retried.
+	MaxWait time.Duration
+}
+
+const (
+	DefaultMaxRetries = 3
+	DefaultMaxWait    = time.Minute
+	// minBackoff is the first wait time of exponential backoff.
+	minBackoff = time.Second
+)
+
+// retryTransport retries requests if GitHub API returns server errors or rate limit errors.
+// Server errors are retried only for idempotent requests,
+// because GitHub may return a server error after it has already processed a write request.
+// It waits for the time specified by the response headers Retry-After and X-RateLimit-Reset,
+// or uses exponential backoff.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+	logger     *slog.Logger
+	now        func() time.Time
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(base http.RoundTripper, retry *Retry, logger *slog.Logger) *retryTransport {
+	return &retryTransport{
+		base:       base,
+		maxRetries: retry.MaxRetries,
+		maxWait:    retry.MaxWait,
+		logger:     logger,
+		now:        time.Now,
+		sleep:      sleep,
+	}
+}
+
+// WithRetry sets the retry transport to the HTTP client.
+// If retry is nil or MaxRetries is 0, the client is returned as is.
+func WithRetry(client *http.Client, retry *Retry, logger *slog.Logger) *http.Client {
+	if retry != nil && retry.MaxRetries > 0 {
+		base := client.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		client.Transport = newRetryTransport(base, retry, logger)
+	}
+	return client
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// the request body was consumed by the previous attempt
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		if attempt >= t.maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		wait, retryable := t.getWait(req, resp, attempt)
+		if !retryable {
+			return resp, nil
+		}
+		if wait > t.maxWait {
+			t.logger.Warn("give up retrying an API request as the wait time is too long",
+				"status_code", resp.StatusCode,
+				"wait", wait,
+				"max_wait", t.maxWait,
+			)
+			return resp, nil
+		}
+		t.logger.Warn("retry an API request",
+			"status_code", resp.StatusCode,
+			"attempt", attempt+1,
+			"wait", wait,
+		)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// getWait returns the wait time before retrying the request and whether the request should be retried.
+func (t *retryTransport) getWait(req *http.Request, resp *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden:
+		if d, ok := t.getRateLimitWait(resp.Header); ok {
+			return d, true
+		}
+		if resp.StatusCode == http.StatusForbidden && !isSecondaryRateLimit(resp) {
+			return 0, false
+		}
+		return backoff(attempt), true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		if !isIdempotent(req) {
+			return 0, false
+		}
+		return backoff(attempt), true
+	case resp.StatusCode == http.StatusOK && strings.HasSuffix(req.URL.Path, "/graphql"):
+		if isGraphQLRateLimited(resp) {
+			if d, ok := t.getRateLimitWait(resp.Header); ok {
+				return d, true
+			}
+			return backoff(attempt), true
+		}
+	}
+	return 0, false
+}
+
+// getRateLimitWait returns the wait time specified by the response headers Retry-After and X-RateLimit-Reset.
+// https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api#exceeding-the-rate-limit
+func (t *retryTransport) getRateLimitWait(header http.Header) (time.Duration, bool) {
+	if s := header.Get("Retry-After"); s != "" {
+		if sec, err := strconv.Atoi(s); err == nil {
+			return time.Duration(sec) * time.Second, true
+		}
+	}
+	if header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return max(time.Unix(reset, 0).Sub(t.now()), 0), true
+}
+
+// isIdempotent returns true if sending the request again doesn't cause any side effect.
+// POST requests aren't idempotent except GraphQL queries.
+func isIdempotent(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return true
+	}
+	if !strings.HasSuffix(req.URL.Path, "/graphql") || req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+	q := struct {
+		Query string `json:"query"`
+	}{}
+	if err := json.NewDecoder(body).Decode(&q); err != nil {
+		return false
+	}
+	return !strings.HasPrefix(strings.TrimSpace(q.Query), "mutation")
+}
+
+func backoff(attempt int) time.Duration {
+	return minBackoff << attempt
+}
+
+// peekBody reads the response body and resets it so that callers can read it again.
+func peekBody(resp *http.Response) []byte {
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	return b
+}
+
+func isSecondaryRateLimit(resp *http.Response) bool {
+	return bytes.Contains(bytes.ToLower(peekBody(resp)), []byte("secondary rate limit"))
+}
+
+func isGraphQLRateLimited(resp *http.Response) bool {
+	body := struct {
+		Errors []struct {
+			Type string `json:"type"`
+		} `json:"errors"`
+	}{}
+	if err := json.Unmarshal(peekBody(resp), &body); err != nil {
+		return false
+	}
+	for _, e := range body.Errors {
+		if e.Type == "RATE_LIMITED" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	code   int
+	header map[string]string
+	body   string
+}
+
+func TestRetryTransport_RoundTrip(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := []struct {
+		title     string
+		method    string
+		path      string
+		body      string
+		responses []*response
+		expCode   int
+		expWaits  []time.Duration
+	}{
+		{
+			title:     "no retry",
+			responses: []*response{{code: http.StatusOK}},
+			expCode:   http.StatusOK,
+		},
+		{
+			title:     "not retry a client error",
+			responses: []*response{{code: http.StatusNotFound}},
+			expCode:   http.StatusNotFound,
+		},
+		{
+			title:  "exponential backoff for server errors",
+			method: http.MethodPatch,
+			responses: []*response{
+				{code: http.StatusBadGateway},
+				{code: http.StatusServiceUnavailable},
+				{code: http.StatusCreated},
+			},
+			expCode:  http.StatusCreated,
+			expWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			title:  "give up retrying",
+			method: http.MethodGet,
+			responses: []*response{
+				{code: http.StatusInternalServerError},
+				{code: http.StatusInternalServerError},
+				{code: http.StatusInternalServerError},
+				{code: http.StatusInternalServerError},
+			},
+			expCode:  http.StatusInternalServerError,
+			expWaits: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			title: "not retry a server error of POST",
+			responses: []*response{
+				{code: http.StatusBadGateway},
+			},
+			expCode: http.StatusBadGateway,
+		},
+		{
+			title: "retry a server error of GraphQL query",
+			path:  "/graphql",
+			body:  `{"query": "query($owner:String!){repository(owner:$owner){id}}"}`,
+			responses: []*response{
+				{code: http.StatusBadGateway},
+				{code: http.StatusOK, body: `{"data": {}}`},
+			},
+			expCode:  http.StatusOK,
+			expWaits: []time.Duration{time.Second},
+		},
+		{
+			title: "not retry a server error of GraphQL mutation",
+			path:  "/graphql",
+			body:  `{"query": "mutation($input:MinimizeCommentInput!){minimizeComment(input:$input){clientMutationId}}"}`,
+			responses: []*response{
+				{code: http.StatusBadGateway},
+			},
+			expCode: http.StatusBadGateway,
+		},
+		{
+			title: "Retry-After",
+			responses: []*response{
+				{code: http.StatusForbidden, header: map[string]string{"Retry-After": "30"}},
+				{code: http.StatusCreated},
+			},
+			expCode:  http.StatusCreated,
+			expWaits: []time.Duration{30 * time.Second},
+		},
+		{
+			title: "X-RateLimit-Reset",
+			responses: []*response{
+				{code: http.StatusTooManyRequests, header: map[string]string{
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     strconv.FormatInt(now.Add(10*time.Second).Unix(), 10),
+				}},
+				{code: http.StatusCreated},
+			},
+			expCode:  http.StatusCreated,
+			expWaits: []time.Duration{10 * time.Second},
+		},
+		{
+			title: "secondary rate limit",
+			responses: []*response{
+				{code: http.StatusForbidden, body: `{"message": "You have exceeded a secondary rate limit."}`},
+				{code: http.StatusCreated},
+			},
+			expCode:  http.StatusCreated,
+			expWaits: []time.Duration{time.Second},
+		},
+		{
+			title: "not retry a permission error",
+			responses: []*response{
+				{code: http.StatusForbidden, body: `{"message": "Resource not accessible by integration"}`},
+			},
+			expCode: http.StatusForbidden,
+		},
+		{
+			title: "the wait time is too long",
+			responses: []*response{
+				{code: http.StatusForbidden, header: map[string]string{"Retry-After": "3600"}},
+			},
+			expCode: http.StatusForbidden,
+		},
+		{
+			title: "GraphQL rate limit",
+			path:  "/graphql",
+			responses: []*response{
+				{code: http.StatusOK, body: `{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`},
+				{code: http.StatusOK, body: `{"data": {}}`},
+			},
+			expCode:  http.StatusOK,
+			expWaits: []time.Duration{time.Second},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			method := d.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			reqBody := d.body
+			if reqBody == "" {
+				reqBody = `{"body": "hello"}`
+			}
+			bodies := []string{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+				resp := d.responses[len(bodies)-1]
+				for k, v := range resp.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(resp.code)
+				_, _ = w.Write([]byte(resp.body))
+			}))
+			t.Cleanup(srv.Close)
+			var waits []time.Duration
+			transport := newRetryTransport(http.DefaultTransport, &Retry{
+				MaxRetries: DefaultMaxRetries,
+				MaxWait:    DefaultMaxWait,
+			}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			transport.now = func() time.Time { return now }
+			transport.sleep = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+			req, err := http.NewRequestWithContext(context.Background(), method, srv.URL+d.path, strings.NewReader(reqBody))
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, d.expCode, resp.StatusCode)
+			require.Len(t, bodies, len(d.responses))
+			for _, b := range bodies {
+				// the request body is sent again
+				require.JSONEq(t, reqBody, b)
+			}
+			require.Equal(t, d.expWaits, waits)
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, d.responses[len(d.responses)-1].body, string(b))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v90/github"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Review is a pull request review with inline comments.
+type Review struct {
+	PRNumber int
+	Org      string
+	Repo     string
+	SHA1     string
+	Body     string
+	// Event is either APPROVE, REQUEST_CHANGES, or COMMENT
+	Event    string
+	Comments []*ReviewComment
+}
+
+type ReviewComment struct {
+	Path string
+	Line int
+	Side string
+	Body string
+}
+
+func (c *Client) CreateReview(ctx context.Context, review *Review) error {
+	err := c.createReview(ctx, review)
+	if err == nil || len(review.Comments) == 0 || !isUnprocessable(err) {
+		return err
+	}
+	// If some lines aren't in the diff, inline comments are moved to the review body.
+	slogerr.WithError(c.logger, err).Warn("move inline comments to the review body as they can't be posted")
+	body := review.Body
+	for _, cmt := range review.Comments {
+		body += "\n\n---\n\n`" + cmt.Path + ":" + strconv.Itoa(cmt.Line) + "`\n\n" + cmt.Body
+	}
+	return c.createReview(ctx, &Review{
+		PRNumber: review.PRNumber,
+		Org:      review.Org,
+		Repo:     review.Repo,
+		SHA1:     review.SHA1,
+		Body:     body,
+		Event:    review.Event,
+	})
+}
+
+func (c *Client) createReview(ctx context.Context, review *Review) error {
+	req := &github.PullRequestReviewRequest{
+		Body:  new(review.Body),
+		Event: new(review.Event),
+	}
+	if review.SHA1 != "" {
+		req.CommitID = new(review.SHA1)
+	}
+	if len(review.Comments) != 0 {
+		req.Comments = make([]*github.DraftReviewComment, len(review.Comments))
+		for i, cmt := range review.Comments {
+			draft := &github.DraftReviewComment{
+				Path: new(cmt.Path),
+				Line: new(cmt.Line),
+				Body: new(cmt.Body),
+			}
+			if cmt.Side != "" {
+				draft.Side = new(cmt.Side)
+			}
+			req.Comments[i] = draft
+		}
+	}
+	if _, _, err := c.pr.CreateReview(ctx, review.Org, review.Repo, review.PRNumber, req); err != nil {
+		return fmt.Errorf("create a pull request review by GitHub API: %w", err)
+	}
+	return nil
+}
@@ -5,7 +5,17 @@ import (
 	"fmt"
 )
 
+// GetAuthenticatedUser returns the login of the authenticated user.
+// If the client is authenticated as a GitHub App, the slug of the app is returned
+// because the login of the app's bot in GraphQL API is the slug.
 func (c *Client) GetAuthenticatedUser(ctx context.Context) (string, error) {
+	if c.apps != nil {
+		app, _, err := c.apps.Get(ctx, "")
+		if err != nil {
+			return "", fmt.Errorf("get the authenticated GitHub App by GitHub API: %w", err)
+		}
+		return app.GetSlug(), nil
+	}
 	user, _, err := c.user.Get(ctx, "")
 	if err != nil {
 		return "", fmt.Errorf("get an authenticated user by GitHub API: %w", err)
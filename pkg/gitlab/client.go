@@ -0,0 +1,54 @@
+package gitlab
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/rest"
+)
+
+// Client is a GitLab API client.
+// Client implements the same API as GitHub client, so GitLab Merge Requests are treated as GitHub Pull Requests.
+// Org and Repo are the namespace and the name of the GitLab project.
+type Client struct {
+	rest   *rest.Client
+	logger *slog.Logger
+}
+
+// DefaultBaseURL is the base URL of GitLab.com API.
+const DefaultBaseURL = "https://gitlab.com/api/v4"
+
+type ParamNew struct {
+	Token string
+	// BaseURL is the base URL of GitLab API. e.g. https://gitlab.example.com/api/v4
+	BaseURL string
+	// Retry is the configuration to retry requests. If Retry is nil, requests aren't retried
+	Retry  *github.Retry
+	Logger *slog.Logger
+}
+
+func New(param *ParamNew) *Client {
+	baseURL := param.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		rest: rest.New(&rest.ParamNew{
+			Name:    "GitLab",
+			BaseURL: baseURL,
+			Header: http.Header{
+				"Private-Token": []string{param.Token},
+			},
+			Retry:  param.Retry,
+			Logger: param.Logger,
+		}),
+		logger: param.Logger,
+	}
+}
+
+// projectPath returns the API path of the project.
+func projectPath(org, repo string) string {
+	return "projects/" + url.PathEscape(org+"/"+repo)
+}
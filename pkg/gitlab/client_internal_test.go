@@ -0,0 +1,178 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+type request struct {
+	Method string
+	Path   string
+}
+
+// newTestClient returns a client which sends requests to a test server.
+// handler returns the status code and the response body of each request.
+func newTestClient(t *testing.T, handler func(r *request) (int, string, http.Header)) (*Client, *[]*request) {
+	t.Helper()
+	requests := []*request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &request{
+			Method: r.Method,
+			Path:   r.URL.RequestURI(),
+		}
+		requests = append(requests, req)
+		code, body, header := handler(req)
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return New(&ParamNew{
+		Token:   "xxx",
+		BaseURL: srv.URL,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}), &requests
+}
+
+func TestClient_CreateComment(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title   string
+		cmt     *github.Comment
+		handler func(r *request) (int, string, http.Header)
+		exp     []string
+	}{
+		{
+			title: "create a note",
+			cmt: &github.Comment{
+				Org:      "foo",
+				Repo:     "bar",
+				PRNumber: 1,
+				Body:     "hello",
+			},
+			exp: []string{"POST /projects/foo%2Fbar/merge_requests/1/notes"},
+		},
+		{
+			title: "update a note",
+			cmt: &github.Comment{
+				Org:       "foo",
+				Repo:      "bar",
+				PRNumber:  1,
+				CommentID: 10,
+				Body:      "hello",
+			},
+			exp: []string{"PUT /projects/foo%2Fbar/merge_requests/1/notes/10"},
+		},
+		{
+			title: "create a commit comment",
+			cmt: &github.Comment{
+				Org:  "foo",
+				Repo: "bar",
+				SHA1: "abc",
+				Body: "hello",
+			},
+			exp: []string{"POST /projects/foo%2Fbar/repository/commits/abc/comments"},
+		},
+		{
+			title: "create a note if a diff note can't be created",
+			cmt: &github.Comment{
+				Org:      "foo",
+				Repo:     "bar",
+				PRNumber: 1,
+				Body:     "hello",
+				Path:     "main.go",
+				Line:     10,
+			},
+			handler: func(r *request) (int, string, http.Header) {
+				if r.Method == http.MethodPost && r.Path == "/projects/foo%2Fbar/merge_requests/1/discussions" {
+					return http.StatusBadRequest, `{"message": "line_code can't be blank"}`, nil
+				}
+				return http.StatusOK, `{"iid": 1, "diff_refs": {"base_sha": "a", "head_sha": "b", "start_sha": "c"}}`, nil
+			},
+			exp: []string{
+				"GET /projects/foo%2Fbar/merge_requests/1",
+				"POST /projects/foo%2Fbar/merge_requests/1/discussions",
+				"POST /projects/foo%2Fbar/merge_requests/1/notes",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			handler := d.handler
+			if handler == nil {
+				handler = func(_ *request) (int, string, http.Header) {
+					return http.StatusCreated, `{}`, nil
+				}
+			}
+			client, requests := newTestClient(t, handler)
+			require.NoError(t, client.CreateComment(context.Background(), d.cmt))
+			paths := make([]string, len(*requests))
+			for i, r := range *requests {
+				paths[i] = r.Method + " " + r.Path
+			}
+			require.Equal(t, d.exp, paths)
+		})
+	}
+}
+
+func TestClient_ListComments(t *testing.T) {
+	t.Parallel()
+	client, _ := newTestClient(t, func(r *request) (int, string, http.Header) {
+		if r.Path == "/projects/foo%2Fbar/merge_requests/1/discussions?per_page=100&page=1" {
+			return http.StatusOK, `[
+  {"id": "d1", "notes": [{"id": 1, "body": "hello", "author": {"username": "octocat"}, "resolvable": true, "resolved": true}]},
+  {"id": "d2", "notes": [{"id": 2, "body": "changed the description", "system": true}]}
+]`, http.Header{"X-Next-Page": []string{"2"}}
+		}
+		return http.StatusOK, `[{"id": "d3", "notes": [{"id": 3, "body": "world", "author": {"username": "octocat"}}]}]`, nil
+	})
+	comments, err := client.ListComments(context.Background(), &github.PullRequest{
+		Org:      "foo",
+		Repo:     "bar",
+		PRNumber: 1,
+	})
+	require.NoError(t, err)
+	exp := []*github.IssueComment{
+		{
+			ID:                "projects/foo%2Fbar/merge_requests/1/discussions/d1",
+			DatabaseID:        1,
+			Kind:              github.CommentKindIssueComment,
+			Body:              "hello",
+			IsMinimized:       true,
+			MinimizedReason:   "resolved",
+			ViewerCanMinimize: true,
+			ViewerCanDelete:   true,
+		},
+		{
+			ID:                "projects/foo%2Fbar/merge_requests/1/notes/3",
+			DatabaseID:        3,
+			Kind:              github.CommentKindIssueComment,
+			Body:              "world",
+			ViewerCanMinimize: true,
+			ViewerCanDelete:   true,
+		},
+	}
+	exp[0].Author.Login = "octocat"
+	exp[1].Author.Login = "octocat"
+	require.Equal(t, exp, comments)
+}
+
+func TestClient_PRNumberWithSHA(t *testing.T) {
+	t.Parallel()
+	client, _ := newTestClient(t, func(_ *request) (int, string, http.Header) {
+		return http.StatusOK, `[{"iid": 1, "state": "merged"}, {"iid": 2, "state": "opened"}]`, nil
+	})
+	iid, err := client.PRNumberWithSHA(context.Background(), "foo", "bar", "abc")
+	require.NoError(t, err)
+	require.Equal(t, 2, iid)
+}
@@ -0,0 +1,54 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type mergeRequest struct {
+	IID      int    `json:"iid"`
+	State    string `json:"state"`
+	DiffRefs struct {
+		BaseSHA  string `json:"base_sha"`
+		HeadSHA  string `json:"head_sha"`
+		StartSHA string `json:"start_sha"`
+	} `json:"diff_refs"`
+}
+
+func (c *Client) getMergeRequest(ctx context.Context, org, repo string, iid int) (*mergeRequest, error) {
+	mr := &mergeRequest{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, mergeRequestPath(org, repo, iid), nil, mr); err != nil {
+		return nil, fmt.Errorf("get a merge request by GitLab API: %w", err)
+	}
+	return mr, nil
+}
+
+// PRNumberWithSHA returns the iid of the merge request associated with the commit.
+// Opened merge requests take precedence over others.
+// If no merge request is found, 0 is returned.
+func (c *Client) PRNumberWithSHA(ctx context.Context, owner, repo, sha string) (int, error) {
+	var mrs []*mergeRequest
+	if _, err := c.rest.Do(ctx, http.MethodGet, projectPath(owner, repo)+"/repository/commits/"+sha+"/merge_requests", nil, &mrs); err != nil {
+		return 0, fmt.Errorf("list merge requests associated with a commit by GitLab API: %w", err)
+	}
+	for _, mr := range mrs {
+		if mr.State == "opened" {
+			return mr.IID, nil
+		}
+	}
+	if len(mrs) == 0 {
+		return 0, nil
+	}
+	return mrs[0].IID, nil
+}
+
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (string, error) {
+	user := struct {
+		Username string `json:"username"`
+	}{}
+	if _, err := c.rest.Do(ctx, http.MethodGet, "user", nil, &user); err != nil {
+		return "", fmt.Errorf("get an authenticated user by GitLab API: %w", err)
+	}
+	return user.Username, nil
+}
@@ -0,0 +1,216 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/rest"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// maxNoteLength is the maximum length of a note body.
+const maxNoteLength = 1000000
+
+type note struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	CreatedAt  time.Time `json:"created_at"`
+	Resolvable bool      `json:"resolvable"`
+	Resolved   bool      `json:"resolved"`
+}
+
+type discussion struct {
+	ID    string  `json:"id"`
+	Notes []*note `json:"notes"`
+}
+
+type notePosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	OldPath      string `json:"old_path,omitempty"`
+	NewPath      string `json:"new_path,omitempty"`
+	OldLine      int    `json:"old_line,omitempty"`
+	NewLine      int    `json:"new_line,omitempty"`
+}
+
+func mergeRequestPath(org, repo string, iid int) string {
+	return projectPath(org, repo) + "/merge_requests/" + strconv.Itoa(iid)
+}
+
+// CreateComment creates or updates a merge request note.
+// If PRNumber is 0, a commit comment is created.
+// If Path is set, a diff note is created.
+// If the line isn't in the diff, a note is created instead.
+func (c *Client) CreateComment(ctx context.Context, cmt *github.Comment) error {
+	body := cmt.Body
+	if len(body) > maxNoteLength {
+		c.logger.Warn("body is too long so it is replaced with `BodyForTooLong`",
+			"body_length", len(body),
+		)
+		body = cmt.BodyForTooLong
+	}
+	if cmt.PRNumber == 0 {
+		return c.createCommitComment(ctx, cmt, body)
+	}
+	mrPath := mergeRequestPath(cmt.Org, cmt.Repo, cmt.PRNumber)
+	if cmt.CommentID != 0 {
+		if _, err := c.rest.Do(ctx, http.MethodPut, mrPath+"/notes/"+strconv.FormatInt(cmt.CommentID, 10), map[string]string{
+			"body": body,
+		}, nil); err != nil {
+			return fmt.Errorf("update a merge request note by GitLab API: %w", err)
+		}
+		return nil
+	}
+	if cmt.Path != "" {
+		err := c.createDiffNote(ctx, cmt, body)
+		if err == nil || !rest.IsStatus(err, http.StatusBadRequest) {
+			return err
+		}
+		slogerr.WithError(c.logger, err).Warn("post the comment to the merge request as the diff note can't be posted",
+			"path", cmt.Path,
+			"line", cmt.Line,
+		)
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPost, mrPath+"/notes", map[string]string{
+		"body": body,
+	}, nil); err != nil {
+		return fmt.Errorf("create a merge request note by GitLab API: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) createCommitComment(ctx context.Context, cmt *github.Comment, body string) error {
+	if cmt.CommentID != 0 {
+		c.logger.Warn("GitLab doesn't support updating commit comments, so a new comment is created")
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPost, projectPath(cmt.Org, cmt.Repo)+"/repository/commits/"+cmt.SHA1+"/comments", map[string]string{
+		"note": body,
+	}, nil); err != nil {
+		return fmt.Errorf("create a commit comment by GitLab API: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) createDiffNote(ctx context.Context, cmt *github.Comment, body string) error {
+	mr, err := c.getMergeRequest(ctx, cmt.Org, cmt.Repo, cmt.PRNumber)
+	if err != nil {
+		return err
+	}
+	position := &notePosition{
+		PositionType: "text",
+		BaseSHA:      mr.DiffRefs.BaseSHA,
+		StartSHA:     mr.DiffRefs.StartSHA,
+		HeadSHA:      mr.DiffRefs.HeadSHA,
+		OldPath:      cmt.Path,
+		NewPath:      cmt.Path,
+	}
+	if cmt.Side == "LEFT" {
+		position.OldLine = cmt.Line
+	} else {
+		position.NewLine = cmt.Line
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPost, mergeRequestPath(cmt.Org, cmt.Repo, cmt.PRNumber)+"/discussions", map[string]any{
+		"body":     body,
+		"position": position,
+	}, nil); err != nil {
+		return fmt.Errorf("create a merge request diff note by GitLab API: %w", err)
+	}
+	return nil
+}
+
+// ListComments lists notes of the merge request.
+// System notes are excluded.
+// The ID of each comment is the API path to hide the note.
+// If the note is resolvable, the note is hidden by resolving the discussion.
+// Otherwise, the note is hidden by deleting the note.
+func (c *Client) ListComments(ctx context.Context, pr *github.PullRequest) ([]*github.IssueComment, error) {
+	mrPath := mergeRequestPath(pr.Org, pr.Repo, pr.PRNumber)
+	comments := []*github.IssueComment{}
+	for page := "1"; page != ""; {
+		var discussions []*discussion
+		resp, err := c.rest.Do(ctx, http.MethodGet, mrPath+"/discussions?per_page=100&page="+page, nil, &discussions)
+		if err != nil {
+			return nil, fmt.Errorf("list merge request discussions by GitLab API: %w", err)
+		}
+		for _, d := range discussions {
+			for _, n := range d.Notes {
+				if n.System {
+					continue
+				}
+				comments = append(comments, convertNote(mrPath, d.ID, n))
+			}
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return comments, nil
+}
+
+func convertNote(mrPath, discussionID string, n *note) *github.IssueComment {
+	cmt := &github.IssueComment{
+		ID:                mrPath + "/notes/" + strconv.FormatInt(n.ID, 10),
+		DatabaseID:        n.ID,
+		Kind:              github.CommentKindIssueComment,
+		Body:              n.Body,
+		CreatedAt:         n.CreatedAt,
+		IsMinimized:       n.Resolved,
+		ViewerCanMinimize: true,
+		ViewerCanDelete:   true,
+	}
+	if n.Resolvable {
+		cmt.ID = mrPath + "/discussions/" + discussionID
+	}
+	if n.Resolved {
+		cmt.MinimizedReason = "resolved"
+	}
+	cmt.Author.Login = n.Author.Username
+	return cmt
+}
+
+// HideComment hides a note.
+// nodeID is the API path returned by ListComments.
+// A discussion is resolved and a note is deleted.
+// GitLab doesn't have classifiers, so classifier is ignored.
+func (c *Client) HideComment(ctx context.Context, nodeID, classifier string) error {
+	if strings.Contains(nodeID, "/discussions/") {
+		if _, err := c.rest.Do(ctx, http.MethodPut, nodeID+"?resolved=true", nil, nil); err != nil {
+			return fmt.Errorf("resolve a merge request discussion by GitLab API: %w", err)
+		}
+		return nil
+	}
+	if _, err := c.rest.Do(ctx, http.MethodDelete, nodeID, nil, nil); err != nil {
+		return fmt.Errorf("delete a merge request note by GitLab API: %w", err)
+	}
+	return nil
+}
+
+// UnhideComment unresolves a discussion.
+// nodeID is the API path returned by ListComments.
+// Deleted notes can't be restored.
+func (c *Client) UnhideComment(ctx context.Context, nodeID string) error {
+	if !strings.Contains(nodeID, "/discussions/") {
+		return errors.New("the note can't be unhidden because the hidden note was deleted")
+	}
+	if _, err := c.rest.Do(ctx, http.MethodPut, nodeID+"?resolved=false", nil, nil); err != nil {
+		return fmt.Errorf("unresolve a merge request discussion by GitLab API: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteComment(ctx context.Context, pr *github.PullRequest, commentID int64) error {
+	if _, err := c.rest.Do(ctx, http.MethodDelete, mergeRequestPath(pr.Org, pr.Repo, pr.PRNumber)+"/notes/"+strconv.FormatInt(commentID, 10), nil, nil); err != nil {
+		return fmt.Errorf("delete a merge request note by GitLab API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// CreateGist creates a private snippet instead of a secret gist and returns the URL.
+func (c *Client) CreateGist(ctx context.Context, gist *github.Gist) (string, error) {
+	snippet := struct {
+		WebURL string `json:"web_url"`
+	}{}
+	if _, err := c.rest.Do(ctx, http.MethodPost, "snippets", map[string]any{
+		"title":      gist.Description,
+		"visibility": "private",
+		"files": []map[string]string{
+			{
+				"file_path": gist.FileName,
+				"content":   gist.Content,
+			},
+		},
+	}, &snippet); err != nil {
+		return "", fmt.Errorf("create a snippet by GitLab API: %w", err)
+	}
+	return snippet.WebURL, nil
+}
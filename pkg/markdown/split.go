@@ -0,0 +1,223 @@
+package markdown
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// block is a fenced code block or a HTML block such as <details> which must not be split.
+type block struct {
+	kind string
+	// open is added at the beginning of the next part if the block is split.
+	open string
+	// close is added at the end of the part if the block is split.
+	close string
+	// fence is the code fence such as ``` and ~~~.
+	fence string
+}
+
+const (
+	kindFence   = "fence"
+	kindDetails = "details"
+	kindPre     = "pre"
+)
+
+type line struct {
+	text string
+	// stack is blocks which are open after the line.
+	stack []*block
+}
+
+var (
+	htmlTagPattern = regexp.MustCompile(`(?i)</?(?:details|pre)\b[^>]*>(?:<code\b[^>]*>)?`)
+	fencePattern   = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})(.*)$")
+)
+
+// Split splits Markdown text into parts whose length is less than or equal to maxLen.
+// Text is split at line boundaries outside fenced code blocks and HTML blocks such as <details> if possible.
+// If a block is too long, the block is closed at the end of the part and reopened at the beginning of the next part
+// so that each part is rendered properly.
+func Split(text string, maxLen int) []string {
+	if len(text) <= maxLen {
+		return []string{text}
+	}
+	lines := parse(text, maxLen/2) //nolint:mnd
+	parts := []string{}
+	var opened []*block
+	for start := 0; start < len(lines); {
+		prefix := openBlocks(opened)
+		size := len(prefix)
+		end := start
+		safeEnd := start
+		for i := start; i < len(lines); i++ {
+			size += len(lines[i].text)
+			if i == len(lines)-1 {
+				if size <= maxLen {
+					end = len(lines)
+				}
+				break
+			}
+			// +1 is a newline added before closing blocks
+			if size+len(closeBlocks(lines[i].stack))+1 > maxLen {
+				break
+			}
+			end = i + 1
+			if len(lines[i].stack) == 0 {
+				safeEnd = i + 1
+			}
+		}
+		if end != len(lines) && safeEnd > start {
+			end = safeEnd
+		}
+		if end == start {
+			// a line is too long even if the line is alone
+			end = start + 1
+		}
+		var b strings.Builder
+		b.WriteString(prefix)
+		for _, l := range lines[start:end] {
+			b.WriteString(l.text)
+		}
+		last := lines[end-1]
+		if end != len(lines) && len(last.stack) != 0 {
+			if !strings.HasSuffix(last.text, "\n") {
+				b.WriteString("\n")
+			}
+			b.WriteString(closeBlocks(last.stack))
+		}
+		parts = append(parts, b.String())
+		opened = last.stack
+		start = end
+	}
+	return parts
+}
+
+// parse splits text into lines and gets blocks which are open after each line.
+// Lines longer than maxLineLen are split.
+func parse(text string, maxLineLen int) []*line {
+	lines := []*line{}
+	var stack []*block
+	for _, t := range strings.SplitAfter(text, "\n") {
+		if t == "" {
+			continue
+		}
+		stack = nextStack(stack, t)
+		for _, chunk := range splitLongLine(t, maxLineLen) {
+			lines = append(lines, &line{
+				text:  chunk,
+				stack: stack,
+			})
+		}
+	}
+	return lines
+}
+
+// nextStack returns blocks which are open after the line.
+// The returned slice is a new slice so that the stack of each line isn't changed.
+func nextStack(stack []*block, text string) []*block {
+	stack = slices.Clone(stack)
+	var top *block
+	if len(stack) != 0 {
+		top = stack[len(stack)-1]
+	}
+	if top != nil && top.kind == kindFence {
+		if isClosingFence(text, top.fence) {
+			return stack[:len(stack)-1]
+		}
+		return stack
+	}
+	if top == nil || top.kind != kindPre {
+		if m := fencePattern.FindStringSubmatch(strings.TrimRight(text, "\n")); m != nil {
+			return append(stack, &block{
+				kind:  kindFence,
+				open:  strings.TrimRight(text, "\n") + "\n",
+				close: m[1] + "\n",
+				fence: m[1],
+			})
+		}
+	}
+	for _, tag := range htmlTagPattern.FindAllString(text, -1) {
+		lower := strings.ToLower(tag)
+		closing := strings.HasPrefix(lower, "</")
+		kind := kindDetails
+		if strings.HasPrefix(strings.TrimLeft(lower, "</"), "pre") {
+			kind = kindPre
+		}
+		if closing {
+			if n := len(stack); n != 0 && stack[n-1].kind == kind {
+				stack = stack[:n-1]
+			}
+			continue
+		}
+		if len(stack) != 0 && stack[len(stack)-1].kind == kindPre {
+			// tags in <pre> are ignored
+			continue
+		}
+		b := &block{
+			kind: kind,
+			open: tag + "\n\n",
+		}
+		switch {
+		case kind == kindDetails:
+			b.close = "\n</details>\n"
+		case strings.Contains(lower, "<code"):
+			b.open = tag + "\n"
+			b.close = "</code></pre>\n"
+		default:
+			b.open = tag + "\n"
+			b.close = "</pre>\n"
+		}
+		stack = append(stack, b)
+	}
+	return stack
+}
+
+func isClosingFence(text, fence string) bool {
+	m := fencePattern.FindStringSubmatch(strings.TrimRight(text, "\n"))
+	if m == nil {
+		return false
+	}
+	return m[1][0] == fence[0] && len(m[1]) >= len(fence) && strings.TrimSpace(m[2]) == ""
+}
+
+// splitLongLine splits the line into chunks whose length is less than or equal to maxLen.
+// The line isn't split in the middle of a multi-byte character.
+func splitLongLine(text string, maxLen int) []string {
+	if len(text) <= maxLen || maxLen <= 0 {
+		return []string{text}
+	}
+	chunks := []string{}
+	for len(text) > maxLen {
+		n := maxLen
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		if n == 0 {
+			n = maxLen
+		}
+		chunks = append(chunks, text[:n])
+		text = text[n:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
+
+func openBlocks(stack []*block) string {
+	var b strings.Builder
+	for _, blk := range stack {
+		b.WriteString(blk.open)
+	}
+	return b.String()
+}
+
+func closeBlocks(stack []*block) string {
+	var b strings.Builder
+	for _, blk := range slices.Backward(stack) {
+		b.WriteString(blk.close)
+	}
+	return b.String()
+}
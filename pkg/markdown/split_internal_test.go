@@ -0,0 +1,92 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title  string
+		text   string
+		maxLen int
+		exp    []string
+	}{
+		{
+			title:  "not split",
+			text:   "hello\nworld\n",
+			maxLen: 100,
+			exp:    []string{"hello\nworld\n"},
+		},
+		{
+			title:  "split at line boundaries",
+			text:   "aaaa\nbbbb\ncccc\n",
+			maxLen: 11,
+			exp:    []string{"aaaa\nbbbb\n", "cccc\n"},
+		},
+		{
+			title:  "not split in a code block",
+			text:   "aaaa\n```\nbbbb\n```\ncccc\n",
+			maxLen: 15,
+			exp:    []string{"aaaa\n", "```\nbbbb\n```\n", "cccc\n"},
+		},
+		{
+			title:  "not split in details",
+			text:   "aaaa\n<details>\nbbbb\n</details>\ncccc\n",
+			maxLen: 32,
+			exp:    []string{"aaaa\n", "<details>\nbbbb\n</details>\ncccc\n"},
+		},
+		{
+			title:  "a too long code block is closed and reopened",
+			text:   "```console\naaaa\nbbbb\ncccc\n```\n",
+			maxLen: 25,
+			exp: []string{
+				"```console\naaaa\n```\n",
+				"```console\nbbbb\n```\n",
+				"```console\ncccc\n```\n",
+			},
+		},
+		{
+			title:  "a too long details is closed and reopened",
+			text:   "<details>\n\n```\naaaa\nbbbb\n```\n\n</details>\n",
+			maxLen: 40,
+			exp: []string{
+				"<details>\n\n```\naaaa\n```\n\n</details>\n",
+				"<details>\n\n```\nbbbb\n```\n\n</details>\n",
+			},
+		},
+		{
+			title:  "pre",
+			text:   "<pre><code>aaaa\n```\nbbbb</code></pre>\n",
+			maxLen: 36,
+			exp: []string{
+				"<pre><code>aaaa\n```\n</code></pre>\n",
+				"<pre><code>\nbbbb</code></pre>\n",
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			parts := Split(d.text, d.maxLen)
+			require.Equal(t, d.exp, parts)
+			for _, part := range parts {
+				require.LessOrEqual(t, len(part), d.maxLen)
+			}
+		})
+	}
+}
+
+func TestSplit_longLine(t *testing.T) {
+	t.Parallel()
+	text := strings.Repeat("あ", 100)
+	parts := Split(text, 100)
+	require.Equal(t, text, strings.Join(parts, ""))
+	for _, part := range parts {
+		require.LessOrEqual(t, len(part), 100)
+		require.True(t, strings.HasPrefix(part, "あ"))
+	}
+}
@@ -2,18 +2,28 @@ package option
 
 import (
 	"errors"
+	"time"
 )
 
 type ExecOptions struct {
 	Options
-	Args        []string
-	SkipComment bool
-	Outputs     []*Output
+	Args            []string
+	SkipComment     bool
+	Outputs         []*Output
+	UpdateCondition string
+	// Progress is true if a comment is posted before the command is run and updated while the command is running
+	Progress bool
+	// ProgressInterval is the interval to update the progress comment
+	ProgressInterval time.Duration
+	// Timeout is the timeout of the command. This takes precedence over the configuration `timeout`
+	Timeout time.Duration
 }
 
 type Output struct {
-	File   string
-	GitHub bool
+	File     string
+	GitHub   bool
+	Review   bool
+	CheckRun string
 }
 
 func ValidateExec(opts *ExecOptions) error {
@@ -9,6 +9,8 @@ type HideOptions struct {
 	HideKey       string
 	Condition     string
 	StdinTemplate bool
+	// Parallelism is the maximum number of comments hidden concurrently
+	Parallelism int
 }
 
 func ValidateHide(opts *HideOptions) error {
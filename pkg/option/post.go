@@ -15,11 +15,13 @@ type Options struct {
 	TemplateKey        string
 	ConfigPath         string
 	LogLevel           string
-	Vars               map[string]string
+	Vars               map[string]any
 	EmbeddedVarNames   []string
 	DryRun             bool
 	SkipNoToken        bool
 	Silent             bool
+	// GitHubApp is true if github-comment authenticates as a GitHub App, so Token isn't required
+	GitHubApp bool
 }
 
 func validate(opts *Options) error {
@@ -29,7 +31,7 @@ func validate(opts *Options) error {
 	if opts.Repo == "" {
 		return errors.New("repo is required")
 	}
-	if opts.Token == "" && !opts.SkipNoToken {
+	if opts.Token == "" && !opts.SkipNoToken && !opts.GitHubApp {
 		return errors.New("token is required")
 	}
 	if opts.SHA1 == "" && opts.PRNumber <= 0 {
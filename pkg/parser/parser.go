@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/sarif"
+)
+
+// Annotation is a finding of a file line.
+// The field names follow GitHub Checks annotations.
+type Annotation struct {
+	Path        string
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+	// Level is either notice, warning, or failure
+	Level   string
+	Message string
+	Title   string
+	RuleID  string
+}
+
+const (
+	LevelNotice  = "notice"
+	LevelWarning = "warning"
+	LevelFailure = "failure"
+)
+
+// gnuPattern matches with the GNU error format.
+// https://www.gnu.org/prep/standards/html_node/Errors.html
+// e.g. main.go:10:5: error: undefined: foo
+var gnuPattern = regexp.MustCompile(`^(?P<path>[^:\s][^:]*):(?P<line>\d+)(?::(?P<column>\d+))?:\s*(?:(?P<level>error|warning|note|info)s?:\s*)?(?P<message>.+)$`)
+
+// Parse parses the input by the format and returns annotations.
+// pattern is used only if the format is regexp.
+func Parse(format, pattern string, input []byte) ([]*Annotation, error) {
+	switch format {
+	case "gnu":
+		return parseRegexp(gnuPattern, input)
+	case "regexp":
+		if pattern == "" {
+			return nil, errors.New("pattern is required if the format is regexp")
+		}
+		p, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile a pattern: %w", err)
+		}
+		return parseRegexp(p, input)
+	case "checkstyle":
+		return parseCheckstyle(input)
+	case "sarif":
+		return parseSARIF(input)
+	default:
+		return nil, errors.New("unknown format. The format must be either gnu, regexp, checkstyle, or sarif: " + format)
+	}
+}
+
+// parseRegexp parses the input line by line.
+// The following named groups are available.
+// path and line are required.
+// path, line, column, end_line, end_column, level, message, title, rule
+func parseRegexp(p *regexp.Regexp, input []byte) ([]*Annotation, error) {
+	annotations := []*Annotation{}
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) //nolint:mnd
+	for scanner.Scan() {
+		m := p.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		groups := make(map[string]string, len(m))
+		for i, name := range p.SubexpNames() {
+			if name != "" {
+				groups[name] = m[i]
+			}
+		}
+		line, err := strconv.Atoi(groups["line"])
+		if err != nil || groups["path"] == "" {
+			continue
+		}
+		annotation := &Annotation{
+			Path:      cleanPath(groups["path"]),
+			StartLine: line,
+			EndLine:   atoi(groups["end_line"], line),
+			Level:     normalizeLevel(groups["level"]),
+			Message:   strings.TrimSpace(groups["message"]),
+			Title:     groups["title"],
+			RuleID:    groups["rule"],
+		}
+		// GitHub Checks annotations support columns only if the annotation is in a single line
+		if col := atoi(groups["column"], 0); col > 0 && annotation.StartLine == annotation.EndLine {
+			annotation.StartColumn = col
+			annotation.EndColumn = atoi(groups["end_column"], col)
+		}
+		annotations = append(annotations, annotation)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read the input: %w", err)
+	}
+	return annotations, nil
+}
+
+type checkstyle struct {
+	Files []struct {
+		Name   string `xml:"name,attr"`
+		Errors []struct {
+			Line     int    `xml:"line,attr"`
+			Column   int    `xml:"column,attr"`
+			Severity string `xml:"severity,attr"`
+			Message  string `xml:"message,attr"`
+			Source   string `xml:"source,attr"`
+		} `xml:"error"`
+	} `xml:"file"`
+}
+
+func parseCheckstyle(input []byte) ([]*Annotation, error) {
+	cs := &checkstyle{}
+	if err := xml.Unmarshal(input, cs); err != nil {
+		return nil, fmt.Errorf("parse checkstyle XML: %w", err)
+	}
+	annotations := []*Annotation{}
+	for _, file := range cs.Files {
+		for _, e := range file.Errors {
+			if e.Line <= 0 {
+				continue
+			}
+			annotation := &Annotation{
+				Path:      cleanPath(file.Name),
+				StartLine: e.Line,
+				EndLine:   e.Line,
+				Level:     normalizeLevel(e.Severity),
+				Message:   e.Message,
+				RuleID:    e.Source,
+			}
+			if e.Column > 0 {
+				annotation.StartColumn = e.Column
+				annotation.EndColumn = e.Column
+			}
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations, nil
+}
+
+func parseSARIF(input []byte) ([]*Annotation, error) {
+	report, err := sarif.Parse(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	annotations := []*Annotation{}
+	for _, result := range report.Results {
+		loc := result.Location
+		if loc == nil || loc.Path == "" || loc.StartLine <= 0 {
+			continue
+		}
+		annotation := &Annotation{
+			Path:      cleanPath(loc.Path),
+			StartLine: loc.StartLine,
+			EndLine:   loc.EndLine,
+			Level:     normalizeLevel(result.Level),
+			Message:   result.Message,
+			Title:     result.RuleID,
+			RuleID:    result.RuleID,
+		}
+		if annotation.EndLine < annotation.StartLine {
+			annotation.EndLine = annotation.StartLine
+		}
+		// GitHub Checks annotations support columns only if the annotation is in a single line
+		if loc.StartColumn > 0 && annotation.StartLine == annotation.EndLine {
+			annotation.StartColumn = loc.StartColumn
+			annotation.EndColumn = max(loc.EndColumn, loc.StartColumn)
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations, nil
+}
+
+// normalizeLevel converts the level of each format to the level of GitHub Checks annotations.
+// If the level is empty or unknown, failure is returned.
+func normalizeLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "note", "info", "notice", "none", "ignore":
+		return LevelNotice
+	case "warning", "warn":
+		return LevelWarning
+	default:
+		return LevelFailure
+	}
+}
+
+func cleanPath(p string) string {
+	return strings.TrimPrefix(p, "./")
+}
+
+func atoi(s string, defaultValue int) int {
+	if s == "" {
+		return defaultValue
+	}
+	a, err := strconv.Atoi(s)
+	if err != nil {
+		return defaultValue
+	}
+	return a
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title   string
+		format  string
+		pattern string
+		input   string
+		exp     []*Annotation
+		isErr   bool
+	}{
+		{
+			title:  "gnu",
+			format: "gnu",
+			input: `./main.go:10:5: undefined: foo
+hello
+pkg/foo.go:3: warning: unused variable`,
+			exp: []*Annotation{
+				{
+					Path:        "main.go",
+					StartLine:   10,
+					EndLine:     10,
+					StartColumn: 5,
+					EndColumn:   5,
+					Level:       LevelFailure,
+					Message:     "undefined: foo",
+				},
+				{
+					Path:      "pkg/foo.go",
+					StartLine: 3,
+					EndLine:   3,
+					Level:     LevelWarning,
+					Message:   "unused variable",
+				},
+			},
+		},
+		{
+			title:   "columns of a multi-line annotation are dropped",
+			format:  "regexp",
+			pattern: `^(?P<path>\S+):(?P<line>\d+)-(?P<end_line>\d+):(?P<column>\d+): (?P<message>.*)$`,
+			input: `main.go:3-5:2: foo
+main.go:7-7:4: bar`,
+			exp: []*Annotation{
+				{
+					Path:      "main.go",
+					StartLine: 3,
+					EndLine:   5,
+					Level:     LevelFailure,
+					Message:   "foo",
+				},
+				{
+					Path:        "main.go",
+					StartLine:   7,
+					EndLine:     7,
+					StartColumn: 4,
+					EndColumn:   4,
+					Level:       LevelFailure,
+					Message:     "bar",
+				},
+			},
+		},
+		{
+			title:   "regexp",
+			format:  "regexp",
+			pattern: `^(?P<level>\w+) (?P<path>\S+) L(?P<line>\d+): (?P<message>.*) \((?P<rule>\w+)\)$`,
+			input:   `info main.go L3: comment is missing (doc)`,
+			exp: []*Annotation{
+				{
+					Path:      "main.go",
+					StartLine: 3,
+					EndLine:   3,
+					Level:     LevelNotice,
+					Message:   "comment is missing",
+					RuleID:    "doc",
+				},
+			},
+		},
+		{
+			title:  "regexp without pattern",
+			format: "regexp",
+			isErr:  true,
+		},
+		{
+			title:  "checkstyle",
+			format: "checkstyle",
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="main.go">
+    <error line="4" column="2" severity="warning" message="exported function should have comment" source="revive"></error>
+  </file>
+</checkstyle>`,
+			exp: []*Annotation{
+				{
+					Path:        "main.go",
+					StartLine:   4,
+					EndLine:     4,
+					StartColumn: 2,
+					EndColumn:   2,
+					Level:       LevelWarning,
+					Message:     "exported function should have comment",
+					RuleID:      "revive",
+				},
+			},
+		},
+		{
+			title:  "sarif",
+			format: "sarif",
+			input: `{
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {"driver": {"name": "tflint", "rules": [{"id": "rule1", "defaultConfiguration": {"level": "note"}}]}},
+      "results": [
+        {
+          "ruleId": "rule1",
+          "message": {"text": "foo"},
+          "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.tf"}, "region": {"startLine": 1, "endLine": 2}}}]
+        },
+        {
+          "ruleId": "rule2",
+          "message": {"text": "no location"}
+        }
+      ]
+    }
+  ]
+}`,
+			exp: []*Annotation{
+				{
+					Path:      "main.tf",
+					StartLine: 1,
+					EndLine:   2,
+					Level:     LevelNotice,
+					Message:   "foo",
+					Title:     "rule1",
+					RuleID:    "rule1",
+				},
+			},
+		},
+		{
+			title:  "unknown format",
+			format: "foo",
+			isErr:  true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			annotations, err := Parse(d.format, d.pattern, []byte(d.input))
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, annotations)
+		})
+	}
+}
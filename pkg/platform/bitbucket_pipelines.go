@@ -0,0 +1,88 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+// BitbucketPipelines gets the repository and pull request from Bitbucket Pipelines' default variables.
+// https://support.atlassian.com/bitbucket-cloud/docs/variables-and-secrets/
+type BitbucketPipelines struct {
+	getenv func(string) string
+}
+
+func NewBitbucketPipelines(param *cienv.Param) *BitbucketPipelines {
+	if param == nil || param.Getenv == nil {
+		return &BitbucketPipelines{
+			getenv: os.Getenv,
+		}
+	}
+	return &BitbucketPipelines{
+		getenv: param.Getenv,
+	}
+}
+
+func (bb *BitbucketPipelines) ID() string {
+	return "bitbucket-pipelines"
+}
+
+func (bb *BitbucketPipelines) Match() bool {
+	return bb.getenv("BITBUCKET_BUILD_NUMBER") != ""
+}
+
+func (bb *BitbucketPipelines) RepoOwner() string {
+	if w := bb.getenv("BITBUCKET_WORKSPACE"); w != "" {
+		return w
+	}
+	return bb.getenv("BITBUCKET_REPO_OWNER")
+}
+
+func (bb *BitbucketPipelines) RepoName() string {
+	return bb.getenv("BITBUCKET_REPO_SLUG")
+}
+
+func (bb *BitbucketPipelines) Ref() string {
+	if tag := bb.Tag(); tag != "" {
+		return tag
+	}
+	return bb.Branch()
+}
+
+func (bb *BitbucketPipelines) Tag() string {
+	return bb.getenv("BITBUCKET_TAG")
+}
+
+func (bb *BitbucketPipelines) Branch() string {
+	return bb.getenv("BITBUCKET_BRANCH")
+}
+
+func (bb *BitbucketPipelines) PRBaseBranch() string {
+	return bb.getenv("BITBUCKET_PR_DESTINATION_BRANCH")
+}
+
+func (bb *BitbucketPipelines) SHA() string {
+	return bb.getenv("BITBUCKET_COMMIT")
+}
+
+func (bb *BitbucketPipelines) IsPR() bool {
+	return bb.getenv("BITBUCKET_PR_ID") != ""
+}
+
+func (bb *BitbucketPipelines) PRNumber() (int, error) {
+	id := bb.getenv("BITBUCKET_PR_ID")
+	if id == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(id)
+	if err == nil {
+		return b, nil
+	}
+	return 0, fmt.Errorf("BITBUCKET_PR_ID is invalid. It failed to parse BITBUCKET_PR_ID as an integer: %w", err)
+}
+
+func (bb *BitbucketPipelines) JobURL() string {
+	return fmt.Sprintf("%s/pipelines/results/%s", bb.getenv("BITBUCKET_GIT_HTTP_ORIGIN"), bb.getenv("BITBUCKET_BUILD_NUMBER"))
+}
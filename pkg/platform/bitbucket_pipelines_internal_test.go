@@ -0,0 +1,108 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestBitbucketPipelines(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title  string
+		env    map[string]string
+		match  bool
+		isPR   bool
+		ref    string
+		jobURL string
+		exp    *option.Options
+		isErr  bool
+	}{
+		{
+			title: "pull request",
+			env: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":          "5",
+				"BITBUCKET_WORKSPACE":             "suzuki-shunsuke",
+				"BITBUCKET_REPO_OWNER":            "owner",
+				"BITBUCKET_REPO_SLUG":             "github-comment",
+				"BITBUCKET_COMMIT":                "abc",
+				"BITBUCKET_BRANCH":                "feature",
+				"BITBUCKET_PR_ID":                 "10",
+				"BITBUCKET_PR_DESTINATION_BRANCH": "main",
+				"BITBUCKET_GIT_HTTP_ORIGIN":       "https://bitbucket.org/suzuki-shunsuke/github-comment",
+			},
+			match:  true,
+			isPR:   true,
+			ref:    "feature",
+			jobURL: "https://bitbucket.org/suzuki-shunsuke/github-comment/pipelines/results/5",
+			exp: &option.Options{
+				Org:      "suzuki-shunsuke",
+				Repo:     "github-comment",
+				SHA1:     "abc",
+				PRNumber: 10,
+			},
+		},
+		{
+			title: "commit",
+			env: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":    "5",
+				"BITBUCKET_REPO_OWNER":      "owner",
+				"BITBUCKET_REPO_SLUG":       "github-comment",
+				"BITBUCKET_COMMIT":          "abc",
+				"BITBUCKET_BRANCH":          "main",
+				"BITBUCKET_TAG":             "v1.0.0",
+				"BITBUCKET_GIT_HTTP_ORIGIN": "https://bitbucket.org/owner/github-comment",
+			},
+			match:  true,
+			ref:    "v1.0.0",
+			jobURL: "https://bitbucket.org/owner/github-comment/pipelines/results/5",
+			exp: &option.Options{
+				Org:  "owner",
+				Repo: "github-comment",
+				SHA1: "abc",
+			},
+		},
+		{
+			title: "invalid pull request id",
+			env: map[string]string{
+				"BITBUCKET_BUILD_NUMBER": "5",
+				"BITBUCKET_PR_ID":        "foo",
+			},
+			match:  true,
+			isPR:   true,
+			jobURL: "/pipelines/results/5",
+			isErr:  true,
+		},
+		{
+			title:  "not Bitbucket Pipelines",
+			env:    map[string]string{},
+			jobURL: "/pipelines/results/",
+			exp:    &option.Options{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			bb := NewBitbucketPipelines(&cienv.Param{
+				Getenv: func(k string) string {
+					return d.env[k]
+				},
+			})
+			require.Equal(t, d.match, bb.Match())
+			require.Equal(t, d.isPR, bb.IsPR())
+			require.Equal(t, d.ref, bb.Ref())
+			require.Equal(t, d.jobURL, bb.JobURL())
+			pt := &Platform{platform: bb}
+			opts := &option.Options{}
+			err := pt.complement(opts)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, opts)
+		})
+	}
+}
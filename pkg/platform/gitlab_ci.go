@@ -0,0 +1,85 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+// GitLabCI gets the repository and merge request from GitLab CI's predefined variables.
+// https://docs.gitlab.com/ee/ci/variables/predefined_variables.html
+type GitLabCI struct {
+	getenv func(string) string
+}
+
+func NewGitLabCI(param *cienv.Param) *GitLabCI {
+	if param == nil || param.Getenv == nil {
+		return &GitLabCI{
+			getenv: os.Getenv,
+		}
+	}
+	return &GitLabCI{
+		getenv: param.Getenv,
+	}
+}
+
+func (gl *GitLabCI) ID() string {
+	return "gitlab-ci"
+}
+
+func (gl *GitLabCI) Match() bool {
+	return gl.getenv("GITLAB_CI") != ""
+}
+
+func (gl *GitLabCI) RepoOwner() string {
+	return gl.getenv("CI_PROJECT_NAMESPACE")
+}
+
+func (gl *GitLabCI) RepoName() string {
+	return gl.getenv("CI_PROJECT_NAME")
+}
+
+func (gl *GitLabCI) Ref() string {
+	return gl.getenv("CI_COMMIT_REF_NAME")
+}
+
+func (gl *GitLabCI) Tag() string {
+	return gl.getenv("CI_COMMIT_TAG")
+}
+
+func (gl *GitLabCI) Branch() string {
+	if b := gl.getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"); b != "" {
+		return b
+	}
+	return gl.getenv("CI_COMMIT_BRANCH")
+}
+
+func (gl *GitLabCI) PRBaseBranch() string {
+	return gl.getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME")
+}
+
+func (gl *GitLabCI) SHA() string {
+	return gl.getenv("CI_COMMIT_SHA")
+}
+
+func (gl *GitLabCI) IsPR() bool {
+	return gl.getenv("CI_MERGE_REQUEST_IID") != ""
+}
+
+func (gl *GitLabCI) PRNumber() (int, error) {
+	iid := gl.getenv("CI_MERGE_REQUEST_IID")
+	if iid == "" {
+		return 0, nil
+	}
+	b, err := strconv.Atoi(iid)
+	if err == nil {
+		return b, nil
+	}
+	return 0, fmt.Errorf("CI_MERGE_REQUEST_IID is invalid. It failed to parse CI_MERGE_REQUEST_IID as an integer: %w", err)
+}
+
+func (gl *GitLabCI) JobURL() string {
+	return gl.getenv("CI_JOB_URL")
+}
@@ -0,0 +1,99 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/option"
+	"github.com/suzuki-shunsuke/go-ci-env/v3/cienv"
+)
+
+func TestGitLabCI(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title  string
+		env    map[string]string
+		match  bool
+		isPR   bool
+		branch string
+		exp    *option.Options
+		isErr  bool
+	}{
+		{
+			title: "merge request",
+			env: map[string]string{
+				"GITLAB_CI":                           "true",
+				"CI_PROJECT_NAMESPACE":                "suzuki-shunsuke",
+				"CI_PROJECT_NAME":                     "github-comment",
+				"CI_COMMIT_SHA":                       "abc",
+				"CI_MERGE_REQUEST_IID":                "10",
+				"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature",
+				"CI_MERGE_REQUEST_TARGET_BRANCH_NAME": "main",
+				"CI_JOB_URL":                          "https://gitlab.com/suzuki-shunsuke/github-comment/-/jobs/1",
+			},
+			match:  true,
+			isPR:   true,
+			branch: "feature",
+			exp: &option.Options{
+				Org:      "suzuki-shunsuke",
+				Repo:     "github-comment",
+				SHA1:     "abc",
+				PRNumber: 10,
+			},
+		},
+		{
+			title: "commit",
+			env: map[string]string{
+				"GITLAB_CI":            "true",
+				"CI_PROJECT_NAMESPACE": "suzuki-shunsuke",
+				"CI_PROJECT_NAME":      "github-comment",
+				"CI_COMMIT_SHA":        "abc",
+				"CI_COMMIT_BRANCH":     "main",
+			},
+			match:  true,
+			branch: "main",
+			exp: &option.Options{
+				Org:  "suzuki-shunsuke",
+				Repo: "github-comment",
+				SHA1: "abc",
+			},
+		},
+		{
+			title: "invalid merge request iid",
+			env: map[string]string{
+				"GITLAB_CI":            "true",
+				"CI_MERGE_REQUEST_IID": "foo",
+			},
+			match: true,
+			isPR:  true,
+			isErr: true,
+		},
+		{
+			title: "not GitLab CI",
+			env:   map[string]string{},
+			exp:   &option.Options{},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			gl := NewGitLabCI(&cienv.Param{
+				Getenv: func(k string) string {
+					return d.env[k]
+				},
+			})
+			require.Equal(t, d.match, gl.Match())
+			require.Equal(t, d.isPR, gl.IsPR())
+			require.Equal(t, d.branch, gl.Branch())
+			pt := &Platform{platform: gl}
+			opts := &option.Options{}
+			err := pt.complement(opts)
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, opts)
+		})
+	}
+}
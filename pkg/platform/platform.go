@@ -17,6 +17,12 @@ func Get() *Platform {
 	cienv.Add(func(param *cienv.Param) cienv.Platform {
 		return NewGoogleCloudBuild(param)
 	})
+	cienv.Add(func(param *cienv.Param) cienv.Platform {
+		return NewGitLabCI(param)
+	})
+	cienv.Add(func(param *cienv.Param) cienv.Platform {
+		return NewBitbucketPipelines(param)
+	})
 	return &Platform{
 		platform: cienv.Get(nil),
 	}
@@ -30,6 +36,10 @@ func (pt *Platform) ComplementHide(opts *option.HideOptions) error {
 	return pt.complement(&opts.Options)
 }
 
+func (pt *Platform) ComplementDelete(opts *option.DeleteOptions) error {
+	return pt.complement(&opts.Options)
+}
+
 func (pt *Platform) CI() string {
 	if pt.platform == nil {
 		return ""
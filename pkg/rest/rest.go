@@ -0,0 +1,111 @@
+// Package rest is a HTTP client of REST API of forges such as GitLab, Gitea, and Bitbucket Server.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// DefaultTimeout is the time limit of a request including retries.
+const DefaultTimeout = 5 * time.Minute
+
+// Client sends requests to REST API.
+type Client struct {
+	httpClient *http.Client
+	name       string
+	baseURL    string
+	header     http.Header
+}
+
+type ParamNew struct {
+	// Name is the name of the API. It is used in error messages. e.g. GitLab
+	Name    string
+	BaseURL string
+	// Header is set to every request. e.g. Authorization
+	Header http.Header
+	// Retry is the configuration to retry requests. If Retry is nil, requests aren't retried
+	Retry  *github.Retry
+	Logger *slog.Logger
+}
+
+func New(param *ParamNew) *Client {
+	return &Client{
+		httpClient: github.WithRetry(&http.Client{
+			Timeout: DefaultTimeout,
+		}, param.Retry, param.Logger),
+		name:    param.Name,
+		baseURL: strings.TrimSuffix(param.BaseURL, "/"),
+		header:  param.Header,
+	}
+}
+
+// APIError is an error response of API.
+type APIError struct {
+	Name       string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API returns an error (status code: %d): %s", e.Name, e.StatusCode, e.Body)
+}
+
+// IsStatus returns true if err is an APIError with the status code.
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// Do sends a request to API.
+// If reqBody isn't nil, it is encoded as JSON.
+// If result isn't nil, the response body is decoded to result.
+func (c *Client) Do(ctx context.Context, method, path string, reqBody, result any) (*http.Response, error) {
+	var body io.Reader
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("encode a request body as JSON: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/"+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("create a HTTP request: %w", err)
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send a HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(resp.Body)
+		return resp, &APIError{
+			Name:       c.name,
+			StatusCode: resp.StatusCode,
+			Body:       string(b),
+		}
+	}
+	if result == nil {
+		return resp, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return resp, fmt.Errorf("decode a response body as JSON: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+func TestClient_Do(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title      string
+		method     string
+		codes      []int
+		reqBody    any
+		isErr      bool
+		expStatus  int
+		expResult  map[string]string
+		expRequest int
+	}{
+		{
+			title:      "normal",
+			method:     http.MethodPost,
+			codes:      []int{http.StatusOK},
+			reqBody:    map[string]string{"body": "hello"},
+			expResult:  map[string]string{"id": "1"},
+			expRequest: 1,
+		},
+		{
+			title:      "server errors of GET requests are retried",
+			method:     http.MethodGet,
+			codes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expResult:  map[string]string{"id": "1"},
+			expRequest: 2,
+		},
+		{
+			title:      "server errors of POST requests aren't retried",
+			method:     http.MethodPost,
+			codes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			reqBody:    map[string]string{"body": "hello"},
+			isErr:      true,
+			expStatus:  http.StatusServiceUnavailable,
+			expRequest: 1,
+		},
+		{
+			title:      "not found",
+			method:     http.MethodGet,
+			codes:      []int{http.StatusNotFound},
+			isErr:      true,
+			expStatus:  http.StatusNotFound,
+			expRequest: 1,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				code := d.codes[requests]
+				requests++
+				require.Equal(t, "token xxx", r.Header.Get("Authorization"))
+				require.Equal(t, "/api/v1/repos", r.URL.Path)
+				if d.reqBody != nil {
+					require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				}
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			}))
+			t.Cleanup(srv.Close)
+			client := New(&ParamNew{
+				Name:    "Gitea",
+				BaseURL: srv.URL + "/api/v1/",
+				Header: http.Header{
+					"Authorization": []string{"token xxx"},
+				},
+				Retry: &github.Retry{
+					MaxRetries: 1,
+					MaxWait:    time.Minute,
+				},
+				Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+			})
+			result := map[string]string{}
+			_, err := client.Do(context.Background(), d.method, "repos", d.reqBody, &result)
+			require.Equal(t, d.expRequest, requests)
+			if d.isErr {
+				require.Error(t, err)
+				require.True(t, IsStatus(err, d.expStatus))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.expResult, result)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Report is results of a SARIF 2.1 log.
+type Report struct {
+	Results []*Result
+}
+
+type Result struct {
+	RuleID string
+	// Level is either error, warning, note, or none
+	Level    string
+	Message  string
+	Location *Location
+}
+
+type LevelResults struct {
+	Level   string
+	Results []*Result
+}
+
+// levels are SARIF result levels in order of severity.
+var levels = []string{"error", "warning", "note", "none"}
+
+// defaultLevel is the default level of SARIF results.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/sarif-v2.1.0-errata01-os-complete.html#_Toc141790898
+const defaultLevel = "warning"
+
+// ByLevel groups results by the level in order of severity.
+// Results whose level is missing or unknown are grouped as warning.
+// Levels without results are excluded.
+func (r *Report) ByLevel() []*LevelResults {
+	m := make(map[string][]*Result, len(levels))
+	for _, result := range r.Results {
+		level := result.Level
+		if !slices.Contains(levels, level) {
+			level = defaultLevel
+		}
+		m[level] = append(m[level], result)
+	}
+	ret := []*LevelResults{}
+	for _, level := range levels {
+		if results, ok := m[level]; ok {
+			ret = append(ret, &LevelResults{
+				Level:   level,
+				Results: results,
+			})
+		}
+	}
+	return ret
+}
+
+type Location struct {
+	Path        string
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+}
+
+type log struct {
+	Version string `json:"version"`
+	Runs    []*run `json:"runs"`
+}
+
+type run struct {
+	Tool struct {
+		Driver struct {
+			Rules []*rule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []*result `json:"results"`
+}
+
+type rule struct {
+	ID                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type result struct {
+	RuleID  string `json:"ruleId"`
+	Level   string `json:"level"`
+	Message struct {
+		Text     string `json:"text"`
+		Markdown string `json:"markdown"`
+	} `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine   int `json:"startLine"`
+				StartColumn int `json:"startColumn"`
+				EndLine     int `json:"endLine"`
+				EndColumn   int `json:"endColumn"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	} `json:"locations"`
+}
+
+// Parse parses a SARIF 2.1 log.
+func Parse(b []byte) (*Report, error) {
+	l := &log{}
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, fmt.Errorf("parse SARIF as JSON: %w", err)
+	}
+	report := &Report{
+		Results: []*Result{},
+	}
+	for _, r := range l.Runs {
+		levels := make(map[string]string, len(r.Tool.Driver.Rules))
+		for _, rl := range r.Tool.Driver.Rules {
+			levels[rl.ID] = rl.DefaultConfiguration.Level
+		}
+		for _, res := range r.Results {
+			report.Results = append(report.Results, convertResult(res, levels))
+		}
+	}
+	return report, nil
+}
+
+func convertResult(res *result, levels map[string]string) *Result {
+	level := res.Level
+	if level == "" {
+		level = levels[res.RuleID]
+	}
+	if level == "" {
+		level = defaultLevel
+	}
+	msg := res.Message.Text
+	if msg == "" {
+		msg = res.Message.Markdown
+	}
+	ret := &Result{
+		RuleID:  res.RuleID,
+		Level:   level,
+		Message: msg,
+	}
+	if len(res.Locations) != 0 {
+		loc := res.Locations[0].PhysicalLocation
+		ret.Location = &Location{
+			Path:        strings.TrimPrefix(loc.ArtifactLocation.URI, "file://"),
+			StartLine:   loc.Region.StartLine,
+			StartColumn: loc.Region.StartColumn,
+			EndLine:     loc.Region.EndLine,
+			EndColumn:   loc.Region.EndColumn,
+		}
+	}
+	return ret
+}
@@ -0,0 +1,140 @@
+package sarif
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	data := []struct {
+		title string
+		input string
+		exp   *Report
+		isErr bool
+	}{
+		{
+			title: "normal",
+			input: `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"rules": [{"id": "G101", "defaultConfiguration": {"level": "error"}}]}},
+    "results": [
+      {
+        "ruleId": "G101",
+        "message": {"text": "hardcoded credentials"},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "file://main.go"},
+          "region": {"startLine": 3, "startColumn": 2, "endLine": 4, "endColumn": 5}
+        }}]
+      },
+      {
+        "ruleId": "G102",
+        "level": "note",
+        "message": {"markdown": "**bind** to all interfaces"}
+      },
+      {
+        "ruleId": "G103",
+        "message": {"text": "unsafe"}
+      }
+    ]
+  }]
+}`,
+			exp: &Report{
+				Results: []*Result{
+					{
+						RuleID:  "G101",
+						Level:   "error",
+						Message: "hardcoded credentials",
+						Location: &Location{
+							Path:        "main.go",
+							StartLine:   3,
+							StartColumn: 2,
+							EndLine:     4,
+							EndColumn:   5,
+						},
+					},
+					{
+						RuleID:  "G102",
+						Level:   "note",
+						Message: "**bind** to all interfaces",
+					},
+					{
+						RuleID:  "G103",
+						Level:   "warning",
+						Message: "unsafe",
+					},
+				},
+			},
+		},
+		{
+			title: "no run",
+			input: `{"version": "2.1.0", "runs": []}`,
+			exp: &Report{
+				Results: []*Result{},
+			},
+		},
+		{
+			title: "invalid JSON",
+			input: `{`,
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			report, err := Parse([]byte(d.input))
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, d.exp, report)
+		})
+	}
+}
+
+func TestReport_ByLevel(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		results []*Result
+		exp     []*LevelResults
+	}{
+		{
+			title: "no result",
+			exp:   []*LevelResults{},
+		},
+		{
+			title: "results are grouped in order of severity",
+			results: []*Result{
+				{RuleID: "a", Level: "note"},
+				{RuleID: "b", Level: "error"},
+				{RuleID: "c", Level: "note"},
+			},
+			exp: []*LevelResults{
+				{Level: "error", Results: []*Result{{RuleID: "b", Level: "error"}}},
+				{Level: "note", Results: []*Result{{RuleID: "a", Level: "note"}, {RuleID: "c", Level: "note"}}},
+			},
+		},
+		{
+			title: "missing and unknown levels are grouped as warning",
+			results: []*Result{
+				{RuleID: "a", Level: "warning"},
+				{RuleID: "b"},
+				{RuleID: "c", Level: "info"},
+			},
+			exp: []*LevelResults{
+				{Level: "warning", Results: []*Result{{RuleID: "a", Level: "warning"}, {RuleID: "b"}, {RuleID: "c", Level: "info"}}},
+			},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			report := &Report{Results: d.results}
+			require.Equal(t, d.exp, report.ByLevel())
+		})
+	}
+}
@@ -44,6 +44,12 @@ func GetTemplates(param *ParamGetTemplates) map[string]string {
 			os.Getenv("GITHUB_REPOSITORY"),
 			os.Getenv("GITHUB_RUN_ID"),
 		),
+		"gitlab-ci": fmt.Sprintf(`[Build link](%s)`, os.Getenv("CI_JOB_URL")),
+		"bitbucket-pipelines": fmt.Sprintf(
+			`[Build link](%s/pipelines/results/%s)`,
+			os.Getenv("BITBUCKET_GIT_HTTP_ORIGIN"),
+			os.Getenv("BITBUCKET_BUILD_NUMBER"),
+		),
 		"cloud-build": fmt.Sprintf(
 			"https://console.cloud.google.com/cloud-build/builds;region=%s/%s?project=%s",
 			cloudBuildRegion,
@@ -59,7 +65,19 @@ func GetTemplates(param *ParamGetTemplates) map[string]string {
 
 {{WrapCode .CombinedOutput}}
 
-</details>`,
+</details>{{if .OverflowURL}}
+
+[The full output]({{.OverflowURL}}){{end}}`,
+		"check_run_summary": `{{template "status" .}} {{template "link" .}}
+
+{{template "join_command" .}}`,
+		"sarif_table": `{{range .ByLevel}}
+### {{.Level}} ({{len .Results}})
+
+| Rule | Location | Message |
+| --- | --- | --- |
+{{range .Results}}| {{.RuleID}} | {{with .Location}}{{.Path}}:{{.StartLine}}{{end}} | {{.Message | replace "|" "\\|" | replace "\n" " "}} |
+{{end}}{{end}}`,
 	}
 
 	ret := map[string]string{
@@ -92,8 +110,11 @@ func avoidHTMLEscape(text string) template.HTML {
 	return template.HTML(text) //nolint:gosec
 }
 
+// MaxWrapCodeLength is the maximum length of the text which WrapCode doesn't truncate.
+const MaxWrapCodeLength = 60000
+
 func wrapCode(text string) any {
-	if len(text) > 60000 { //nolint:mnd
+	if len(text) > MaxWrapCodeLength {
 		text = text[:20000] + `
 
 # ...
@@ -102,6 +123,10 @@ func wrapCode(text string) any {
 
 ` + text[len(text)-20000:]
 	}
+	return wrapCodeWithoutTruncation(text)
+}
+
+func wrapCodeWithoutTruncation(text string) any {
 	if strings.Contains(text, "```") {
 		return template.HTML("<pre><code>" + template.HTMLEscapeString(text) + "</code></pre>") //nolint:gosec
 	}
@@ -109,6 +134,16 @@ func wrapCode(text string) any {
 }
 
 func (r *Renderer) Render(tpl string, templates map[string]string, params any) (string, error) {
+	return r.render(tpl, templates, params, wrapCode)
+}
+
+// RenderWithoutTruncation renders the template like Render, but WrapCode doesn't truncate the text.
+// This is used to split a too long comment into multiple comments.
+func (r *Renderer) RenderWithoutTruncation(tpl string, templates map[string]string, params any) (string, error) {
+	return r.render(tpl, templates, params, wrapCodeWithoutTruncation)
+}
+
+func (r *Renderer) render(tpl string, templates map[string]string, params any, wrapCodeFunc func(string) any) (string, error) {
 	tpl = addTemplates(tpl, templates)
 
 	// delete some functions for security reason
@@ -118,7 +153,7 @@ func (r *Renderer) Render(tpl string, templates map[string]string, params any) (
 	delete(funcs, "getHostByName")
 	tmpl, err := template.New("comment").Funcs(template.FuncMap{
 		"AvoidHTMLEscape": avoidHTMLEscape,
-		"WrapCode":        wrapCode,
+		"WrapCode":        wrapCodeFunc,
 	}).Funcs(funcs).Parse(tpl)
 	if err != nil {
 		return "", fmt.Errorf("parse a template: %w", err)
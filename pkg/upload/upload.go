@@ -0,0 +1,68 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+// Client uploads a too long command output to an HTTP endpoint instead of a secret gist.
+// This is useful if gists aren't available, e.g. in GitHub Enterprise Server where gists are disabled.
+type Client struct {
+	httpClient *http.Client
+	url        string
+	token      string
+}
+
+type ParamNew struct {
+	// URL is the endpoint where the output is uploaded
+	URL string
+	// Token is sent as a bearer token if it isn't empty
+	Token string
+}
+
+func New(param *ParamNew) *Client {
+	return &Client{
+		httpClient: http.DefaultClient,
+		url:        param.URL,
+		token:      param.Token,
+	}
+}
+
+// CreateGist uploads the content by a POST request and returns the URL of the uploaded content.
+// The endpoint must return the URL in the response header Location.
+// The description and the file name are sent as the request headers X-GitHub-Comment-Description and X-GitHub-Comment-File-Name.
+func (c *Client) CreateGist(ctx context.Context, gist *github.Gist) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, strings.NewReader(gist.Content))
+	if err != nil {
+		return "", fmt.Errorf("create a HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("X-GitHub-Comment-Description", gist.Description)
+	req.Header.Set("X-GitHub-Comment-File-Name", gist.FileName)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("send a HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("upload the command output (status code: %d): %s", resp.StatusCode, string(b))
+	}
+	u, err := resp.Location()
+	if err != nil {
+		if errors.Is(err, http.ErrNoLocation) {
+			return "", errors.New("the upload endpoint doesn't return the response header Location")
+		}
+		return "", fmt.Errorf("parse the response header Location: %w", err)
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,86 @@
+package upload
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/suzuki-shunsuke/github-comment/v6/pkg/github"
+)
+
+func TestClient_CreateGist(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title    string
+		token    string
+		code     int
+		location string
+		exp      string
+		isErr    bool
+	}{
+		{
+			title:    "normal",
+			token:    "xxx",
+			code:     http.StatusCreated,
+			location: "https://logs.example.com/foo",
+			exp:      "https://logs.example.com/foo",
+		},
+		{
+			title:    "a relative location is resolved",
+			code:     http.StatusCreated,
+			location: "/logs/foo",
+			exp:      "/logs/foo",
+		},
+		{
+			title: "no location",
+			code:  http.StatusCreated,
+			isErr: true,
+		},
+		{
+			title: "error",
+			code:  http.StatusInternalServerError,
+			isErr: true,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.title, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodPost || string(b) != "hello" || r.Header.Get("X-GitHub-Comment-File-Name") != "output.log" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if d.token != "" && r.Header.Get("Authorization") != "Bearer "+d.token {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if d.location != "" {
+					w.Header().Set("Location", d.location)
+				}
+				w.WriteHeader(d.code)
+			}))
+			t.Cleanup(srv.Close)
+			client := New(&ParamNew{URL: srv.URL + "/upload", Token: d.token})
+			u, err := client.CreateGist(context.Background(), &github.Gist{
+				Description: "foo",
+				FileName:    "output.log",
+				Content:     "hello",
+			})
+			if d.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			exp := d.exp
+			if strings.HasPrefix(exp, "/") {
+				exp = srv.URL + exp
+			}
+			require.Equal(t, exp, u)
+		})
+	}
+}